@@ -0,0 +1,192 @@
+package enum
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBusClosed is returned by Publish of closed bus.
+var ErrBusClosed = errors.New("enum: bus closed")
+
+// BusOption configures Bus.
+type BusOption func(*Bus)
+
+// AsyncDelivery makes Bus deliver values to subscribers on the pool of workers goroutines.
+// Publish returns once the deliveries are queued, and errors of subscribers are passed to DeliveryErrorHandler.
+// The queue is unbounded, so subscribers publishing on the workers never wait for each other.
+func AsyncDelivery(workers int) BusOption {
+	return func(b *Bus) {
+		if workers < 1 {
+			workers = 1
+		}
+		b.workers = workers
+	}
+}
+
+// DeliveryErrorHandler sets the handler of errors returned by subscribers in asynchronous delivery.
+func DeliveryErrorHandler(fn func(error)) BusOption {
+	return func(b *Bus) {
+		b.onError = fn
+	}
+}
+
+type subscriber struct {
+	fn func(context.Context, any) error
+}
+
+// Bus is the in-process runtime of the bus generated by enumgen, which delivers published values to subscribers of the key.
+// It is safe for concurrent use.
+type Bus struct {
+	workers int
+	onError func(error)
+
+	mu          sync.RWMutex
+	subscribers map[string][]*subscriber
+	closed      bool
+
+	publishing  sync.WaitGroup // ongoing Publish
+	jobsMu      sync.Mutex
+	jobsCond    *sync.Cond // signaled when a job is queued or the workers are stopped
+	jobs        []func()   // queue of asynchronous deliveries
+	stopped     bool
+	workerGroup sync.WaitGroup
+}
+
+// NewBus returns Bus, which delivers values synchronously unless AsyncDelivery is specified.
+func NewBus(opts ...BusOption) *Bus {
+	b := &Bus{
+		subscribers: map[string][]*subscriber{},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.workers > 0 {
+		b.jobsCond = sync.NewCond(&b.jobsMu)
+		b.workerGroup.Add(b.workers)
+		for i := 0; i < b.workers; i++ {
+			go b.work()
+		}
+	}
+	return b
+}
+
+// work runs queued jobs until the workers are stopped and the queue is drained.
+func (b *Bus) work() {
+	defer b.workerGroup.Done()
+	for {
+		b.jobsMu.Lock()
+		for len(b.jobs) == 0 && !b.stopped {
+			b.jobsCond.Wait()
+		}
+		if len(b.jobs) == 0 {
+			b.jobsMu.Unlock()
+			return
+		}
+		job := b.jobs[0]
+		b.jobs[0] = nil
+		b.jobs = b.jobs[1:]
+		b.jobsMu.Unlock()
+
+		job()
+	}
+}
+
+// Subscribe registers fn as the subscriber of the key, and returns the function to unsubscribe it.
+// fn receives the context passed to Publish. In asynchronous delivery, it is not canceled with the publisher's.
+func (b *Bus) Subscribe(key string, fn func(ctx context.Context, v any) error) (unsubscribe func()) {
+	s := &subscriber{
+		fn: fn,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// copy on write, the slice may be held by ongoing Publish
+	subscribers := b.subscribers[key]
+	b.subscribers[key] = append(subscribers[:len(subscribers):len(subscribers)], s)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			subscribers := b.subscribers[key]
+			rest := make([]*subscriber, 0, len(subscribers))
+			for _, sub := range subscribers {
+				if sub != s {
+					rest = append(rest, sub)
+				}
+			}
+			b.subscribers[key] = rest
+		})
+	}
+}
+
+// Publish delivers v to the subscribers of the key in order of subscription.
+// In synchronous delivery, errors of all subscribers are joined, and the remaining subscribers are skipped once ctx is done.
+// In asynchronous delivery, it returns once the deliveries are queued.
+func (b *Bus) Publish(ctx context.Context, key string, v any) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBusClosed
+	}
+	subscribers := b.subscribers[key]
+	b.publishing.Add(1)
+	b.mu.RUnlock()
+	// subscribers may subscribe or publish during delivery, so the lock is not held
+	defer b.publishing.Done()
+
+	if b.workers == 0 {
+		errs := make([]error, 0, len(subscribers))
+		for _, s := range subscribers {
+			if err := ctx.Err(); err != nil {
+				errs = append(errs, err)
+				break
+			}
+			errs = append(errs, s.fn(ctx, v))
+		}
+		return errors.Join(errs...)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// deliveries outlive Publish, so they must not be canceled with the publisher
+	ctx = context.WithoutCancel(ctx)
+	b.jobsMu.Lock()
+	defer b.jobsMu.Unlock()
+	for _, s := range subscribers {
+		s := s
+		b.jobs = append(b.jobs, func() {
+			if err := s.fn(ctx, v); err != nil && b.onError != nil {
+				b.onError(err)
+			}
+		})
+		b.jobsCond.Signal()
+	}
+	return nil
+}
+
+// Close stops accepting values, and waits for queued deliveries to complete.
+// Publish from the subscribers during Close also returns ErrBusClosed.
+// It must not be called from subscribers.
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	// no more Publish starts, wait for ongoing ones to queue their deliveries
+	b.publishing.Wait()
+	if b.workers > 0 {
+		b.jobsMu.Lock()
+		b.stopped = true
+		b.jobsCond.Broadcast()
+		b.jobsMu.Unlock()
+	}
+	b.workerGroup.Wait()
+	return nil
+}
@@ -0,0 +1,261 @@
+package enum_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+// closeWithin fails the test if Close does not return in time, e.g. because of deadlock.
+func closeWithin(t *testing.T, b *enum.Bus, d time.Duration) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = b.Close()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatal("Close did not return")
+	}
+}
+
+func TestBusSync(t *testing.T) {
+	b := enum.NewBus()
+	defer b.Close()
+
+	var got []string
+	errA := errors.New("a")
+	unsubscribe := b.Subscribe("key", func(_ context.Context, v any) error {
+		got = append(got, "a:"+v.(string))
+		return errA
+	})
+	b.Subscribe("key", func(_ context.Context, v any) error {
+		got = append(got, "b:"+v.(string))
+		return nil
+	})
+	b.Subscribe("other", func(_ context.Context, v any) error {
+		got = append(got, "other:"+v.(string))
+		return nil
+	})
+
+	if err := b.Publish(context.Background(), "key", "1"); !errors.Is(err, errA) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	unsubscribe()
+	unsubscribe() // no-op
+	if err := b.Publish(context.Background(), "key", "2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := b.Publish(context.Background(), "none", "3"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	want := []string{"a:1", "b:1", "b:2"}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestBusSyncContext(t *testing.T) {
+	b := enum.NewBus()
+	defer b.Close()
+
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	defer cancel()
+
+	var called []int
+	b.Subscribe("key", func(ctx context.Context, _ any) error {
+		if ctx.Value(key{}) != "value" {
+			t.Error("subscriber did not receive the context of Publish")
+		}
+		called = append(called, 1)
+		cancel()
+		return nil
+	})
+	b.Subscribe("key", func(context.Context, any) error {
+		called = append(called, 2)
+		return nil
+	})
+
+	// the subscribers following cancellation are skipped
+	if err := b.Publish(ctx, "key", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(called) != 1 {
+		t.Errorf("unexpected subscribers called: %v", called)
+	}
+}
+
+func TestBusAsync(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		errs   []error
+		errSub = errors.New("subscriber")
+	)
+	b := enum.NewBus(
+		enum.AsyncDelivery(4),
+		enum.DeliveryErrorHandler(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	)
+
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+
+	var delivered atomic.Int64
+	b.Subscribe("key", func(ctx context.Context, _ any) error {
+		// delivered after the publisher's context is canceled
+		time.Sleep(time.Millisecond)
+		if ctx.Value(key{}) != "value" || ctx.Err() != nil {
+			t.Error("subscriber did not receive the context of Publish without cancellation")
+		}
+		delivered.Add(1)
+		return errSub
+	})
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		if err := b.Publish(ctx, "key", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel()
+	if err := b.Publish(ctx, "key", n); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Close drains queued deliveries
+	closeWithin(t, b, 5*time.Second)
+	if got := delivered.Load(); got != n {
+		t.Errorf("delivered %d values, want %d", got, n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != n || !errors.Is(errs[0], errSub) {
+		t.Errorf("unexpected errors passed to handler: %v", errs)
+	}
+}
+
+func TestBusClosed(t *testing.T) {
+	for name, opts := range map[string][]enum.BusOption{
+		"sync":  nil,
+		"async": {enum.AsyncDelivery(1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := enum.NewBus(opts...)
+			b.Subscribe("key", func(context.Context, any) error {
+				t.Error("delivered after Close")
+				return nil
+			})
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Close(); err != nil {
+				t.Errorf("unexpected error of second Close: %v", err)
+			}
+			if err := b.Publish(context.Background(), "key", nil); !errors.Is(err, enum.ErrBusClosed) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBusReentrant(t *testing.T) {
+	for name, opts := range map[string][]enum.BusOption{
+		"sync":  nil,
+		"async": {enum.AsyncDelivery(1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := enum.NewBus(opts...)
+
+			const n = 100
+			var (
+				delivered atomic.Int64
+				done      = make(chan struct{})
+			)
+			b.Subscribe("leaf", func(context.Context, any) error {
+				if delivered.Add(1) == n {
+					close(done)
+				}
+				return nil
+			})
+			b.Subscribe("root", func(ctx context.Context, _ any) error {
+				// subscribe and publish more values than workers from the subscriber
+				unsubscribe := b.Subscribe("leaf", func(context.Context, any) error {
+					return nil
+				})
+				defer unsubscribe()
+				for i := 0; i < n; i++ {
+					if err := b.Publish(ctx, "leaf", i); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+			if err := b.Publish(context.Background(), "root", nil); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("delivered %d values, want %d", delivered.Load(), n)
+			}
+			closeWithin(t, b, 5*time.Second)
+		})
+	}
+}
+
+func TestBusConcurrent(t *testing.T) {
+	for name, opts := range map[string][]enum.BusOption{
+		"sync":  nil,
+		"async": {enum.AsyncDelivery(4)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := enum.NewBus(opts...)
+
+			var (
+				wg        sync.WaitGroup
+				delivered atomic.Int64
+			)
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						unsubscribe := b.Subscribe("key", func(context.Context, any) error {
+							delivered.Add(1)
+							return nil
+						})
+						if err := b.Publish(context.Background(), "key", j); err != nil {
+							t.Error(err)
+						}
+						unsubscribe()
+					}
+				}()
+			}
+			wg.Wait()
+			closeWithin(t, b, 5*time.Second)
+
+			// each Publish is delivered to at least the subscriber of its own goroutine
+			if got := delivered.Load(); got < 800 {
+				t.Errorf("delivered %d values, want at least 800", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/daichitakahashi/go-enum/enumcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(enumcheck.Analyzer)
+}
@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/daichitakahashi/go-enum/cmd/enumgen/gen"
@@ -10,8 +15,10 @@ import (
 )
 
 var rootCmd = &cobra.Command{
-	Use:  "generate enum for Go",
-	RunE: run,
+	Use:          "enumgen [packages]",
+	Short:        "generate enum for Go",
+	RunE:         run,
+	SilenceUsage: true,
 }
 
 var (
@@ -20,15 +27,45 @@ var (
 	visitors     []string
 	accepts      []string
 	visitorImpls []string
+	visitorFuncs []string
+	sealed       []string
+	matches      []string
+	multis       []string
+	jsons        []string
+	kinds        []string
+	byNames      []string
+	lists        []string
+	unions       []string
+	flattens     []string
+	pointers     []string
+	unimpls      []string
+	wraps        []string
+	buses        []string
+	check        bool
 )
 
 func init() {
 	flags := rootCmd.Flags()
 	flags.StringVar(&wd, "wd", ".", "working directory")
-	flags.StringVar(&out, "out", "enum.gen.go", "output file name")
+	flags.StringVar(&out, "out", gen.DefaultFilename, "output file name")
 	flags.StringSliceVar(&visitors, "visitor", nil, "")
 	flags.StringSliceVar(&accepts, "accept", nil, "")
 	flags.StringSliceVar(&visitorImpls, "visitor-impl", nil, "")
+	flags.StringSliceVar(&visitorFuncs, "visitor-funcs", nil, "generate visitor built from handler funcs with default fallback")
+	flags.StringSliceVar(&sealed, "sealed", nil, "seal enum identifiers matched with the pattern")
+	flags.StringSliceVar(&matches, "match", nil, "generate generic match function")
+	flags.StringSliceVar(&multis, "multi", nil, "generate visitor fanning out to multiple visitors")
+	flags.StringSliceVar(&kinds, "kind", nil, "generate Kind type and methods of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&pointers, "pointer", nil, "refer members of enum identifiers matched with the pattern by pointer")
+	flags.StringSliceVar(&flattens, "flatten", nil, "visit members of nested enums directly in visitor of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&unimpls, "unimplemented", nil, "generate unimplemented and no-op visitors of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&wraps, "wrap", nil, "generate visitor middleware of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&buses, "bus", nil, "generate in-process bus of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&lists, "list", nil, "generate listing and iteration of members of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&unions, "union", nil, "generate union constraint of members and Is and As functions of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&byNames, "by-name", nil, "generate constructor by member name of enum identifiers matched with the pattern")
+	flags.StringSliceVar(&jsons, "json", nil, "generate JSON encoding functions of enum identifiers matched with the pattern")
+	flags.BoolVar(&check, "check", false, "verify generated files are up to date without writing")
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -36,7 +73,7 @@ func run(cmd *cobra.Command, args []string) error {
 	for _, v := range visitors {
 		params, err := parseNamingVisitorParams(v)
 		if err != nil {
-			log.Fatalf("visitor: %s", err)
+			return fmt.Errorf("visitor: %w", err)
 		}
 		namingVisitorParams = append(namingVisitorParams, *params)
 	}
@@ -44,7 +81,7 @@ func run(cmd *cobra.Command, args []string) error {
 	for _, a := range accepts {
 		params, err := parseNamingAcceptParams(a)
 		if err != nil {
-			log.Fatalf("accept: %s", err)
+			return fmt.Errorf("accept: %w", err)
 		}
 		namingAcceptParams = append(namingAcceptParams, *params)
 	}
@@ -52,7 +89,115 @@ func run(cmd *cobra.Command, args []string) error {
 	for _, f := range visitorImpls {
 		namingVisitorImplParams = append(namingVisitorImplParams, parseNamingVisitorFactoryParams(f))
 	}
-	gen.Run(wd, out, namingVisitorParams, namingAcceptParams, namingVisitorImplParams)
+	namingVisitorFuncsParams := make([]gen.NamingVisitorFuncsParams, 0, len(visitorFuncs))
+	for _, f := range visitorFuncs {
+		namingVisitorFuncsParams = append(namingVisitorFuncsParams, parseNamingVisitorFuncsParams(f))
+	}
+
+	namingMatchParams := make([]gen.NamingMatchParams, 0, len(matches))
+	for _, m := range matches {
+		namingMatchParams = append(namingMatchParams, parseNamingMatchParams(m))
+	}
+	namingKindParams := make([]gen.NamingKindParams, 0, len(kinds))
+	for _, k := range kinds {
+		namingKindParams = append(namingKindParams, parseNamingKindParams(k))
+	}
+	namingMultiParams := make([]gen.NamingMultiParams, 0, len(multis))
+	for _, m := range multis {
+		params, err := parseNamingMultiParams(m)
+		if err != nil {
+			return fmt.Errorf("multi: %w", err)
+		}
+		namingMultiParams = append(namingMultiParams, *params)
+	}
+
+	files, err := gen.Generate(cmd.Context(), gen.Config{
+		Dir:           wd,
+		Patterns:      args,
+		Filename:      out,
+		Visitors:      namingVisitorParams,
+		Accepts:       namingAcceptParams,
+		VisitorImpls:  namingVisitorImplParams,
+		VisitorFuncs:  namingVisitorFuncsParams,
+		Sealed:        sealed,
+		Matches:       namingMatchParams,
+		Multis:        namingMultiParams,
+		JSON:          jsons,
+		Kinds:         namingKindParams,
+		ByName:        byNames,
+		Lists:         lists,
+		Union:         unions,
+		Flatten:       flattens,
+		Pointer:       pointers,
+		Unimplemented: unimpls,
+		Wrap:          wraps,
+		Bus:           buses,
+	})
+	// write files generated successfully even if some packages failed
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	if check {
+		if checkErr := checkFiles(cmd, filenames, files); checkErr != nil {
+			return errors.Join(err, checkErr)
+		}
+		return err
+	}
+	for _, filename := range filenames {
+		code := files[filename]
+		if code == nil {
+			// stale file of the package which no longer declares enums
+			if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			if len(args) > 0 {
+				cmd.PrintErrf("removed %s\n", filename)
+			}
+			continue
+		}
+		err := os.WriteFile(filename, code, 0644)
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			cmd.PrintErrf("generated %s\n", filename)
+		}
+	}
+	return err
+}
+
+// Compare generated code with existing files and print unified diff of the files out of date.
+func checkFiles(cmd *cobra.Command, filenames []string, files map[string][]byte) error {
+	base, err := filepath.Abs(wd)
+	if err != nil {
+		return err
+	}
+	var outdated int
+	for _, filename := range filenames {
+		current, err := os.ReadFile(filename)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		name := filename
+		if rel, err := filepath.Rel(base, filename); err == nil {
+			name = filepath.ToSlash(rel)
+		}
+		newName := "b/" + name
+		if files[filename] == nil {
+			// stale file to be removed
+			newName = "/dev/null"
+		}
+		diff := unifiedDiff("a/"+name, newName, current, files[filename])
+		if diff != "" {
+			fmt.Fprint(cmd.OutOrStdout(), diff)
+			outdated++
+		}
+	}
+	if outdated > 0 {
+		return fmt.Errorf("%d generated file(s) are out of date", outdated)
+	}
 	return nil
 }
 
@@ -94,6 +239,68 @@ func parseNamingVisitorFactoryParams(s string) gen.NamingVisitorImplParams {
 	}
 }
 
+// --visitor-funcs="*Event"
+// --visitor-funcs="*Event:*Funcs"
+func parseNamingVisitorFuncsParams(s string) gen.NamingVisitorFuncsParams {
+	target, name, ok := strings.Cut(s, ":")
+	if !ok {
+		name = "*Funcs"
+	}
+	return gen.NamingVisitorFuncsParams{
+		Target:   target,
+		TypeName: name,
+	}
+}
+
+// --match="*Event"
+// --match="*Event:Match*"
+func parseNamingMatchParams(s string) gen.NamingMatchParams {
+	target, name, ok := strings.Cut(s, ":")
+	if !ok {
+		name = "Match*"
+	}
+	return gen.NamingMatchParams{
+		Target:   target,
+		FuncName: name,
+	}
+}
+
+// --kind="*Event"
+// --kind="*Event:*Kind"
+func parseNamingKindParams(s string) gen.NamingKindParams {
+	target, name, ok := strings.Cut(s, ":")
+	if !ok {
+		name = "Kind"
+	}
+	return gen.NamingKindParams{
+		Target:     target,
+		MethodName: name,
+	}
+}
+
+// --multi="*Event"
+// --multi="*Event:Multi*"
+// --multi="*Event:Multi*:join"
+func parseNamingMultiParams(s string) (*gen.NamingMultiParams, error) {
+	parts := strings.SplitN(s, ":", 3)
+	params := &gen.NamingMultiParams{
+		Target:   parts[0],
+		FuncName: "Multi*",
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		params.FuncName = parts[1]
+	}
+	if len(parts) > 2 {
+		if parts[2] != "join" {
+			return nil, fmt.Errorf("invalid format %q", s)
+		}
+		params.Join = true
+	}
+	return params, nil
+}
+
 func Run() {
-	_ = rootCmd.Execute()
+	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// execute runs enumgen with args, and returns the output to stdout.
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	check = false // flags are kept between executions
+	var stdout bytes.Buffer
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+	err := rootCmd.Execute()
+	return stdout.String(), err
+}
+
+// writeStaleModule writes the module without dependencies, whose package declares no enum but has the file generated previously.
+func writeStaleModule(t *testing.T) (dir, stale string) {
+	t.Helper()
+	dir = t.TempDir()
+	stale = filepath.Join(dir, "enum.gen.go")
+	for name, content := range map[string]string{
+		"go.mod":      "module stale\n\ngo 1.22\n",
+		"stale.go":    "package stale\n\ntype Fruits interface{}\n",
+		"enum.gen.go": "// Code generated by enumgen. DO NOT EDIT.\n\npackage stale\n\ntype FruitsVisitor interface {\n\tVisitApple(e Apple)\n}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, stale
+}
+
+func TestCheckStaleFile(t *testing.T) {
+	dir, stale := writeStaleModule(t)
+
+	stdout, err := execute(t, "--wd", dir, "--check")
+	if err == nil || !strings.Contains(err.Error(), "1 generated file(s) are out of date") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(stdout, "--- a/enum.gen.go\n+++ /dev/null\n@@ -1,7 +0,0 @@\n") {
+		t.Errorf("unexpected diff:\n%s", stdout)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Errorf("stale file must be kept in check mode: %v", err)
+	}
+}
+
+func TestRemoveStaleFile(t *testing.T) {
+	dir, stale := writeStaleModule(t)
+
+	if _, err := execute(t, "--wd", dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("stale file is not removed: %v", err)
+	}
+
+	// nothing to do after removal
+	if _, err := execute(t, "--wd", dir, "--check"); err == nil {
+		t.Error("package without enum must be reported")
+	}
+}
+
+// The file not generated by enumgen is never touched, even if it has the same name.
+func TestKeepNonGeneratedFile(t *testing.T) {
+	dir, stale := writeStaleModule(t)
+	if err := os.WriteFile(stale, []byte("package stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := execute(t, "--wd", dir, "--check"); err == nil || strings.Contains(err.Error(), "out of date") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := execute(t, "--wd", dir); err == nil {
+		t.Error("package without enum must be reported")
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Errorf("non-generated file is removed: %v", err)
+	}
+}
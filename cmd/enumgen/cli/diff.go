@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Compute the shortest edit script using LCS after trimming common prefix and suffix.
+func diffLines(a, b []string) []diffOp {
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	ops = appendLCSDiff(ops, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops
+}
+
+// appendLCSDiff appends the edit script from a to b, computed by Hirschberg's algorithm.
+// Unlike the full LCS table, it requires only linear space(O(len(b))) even for large rewritten files.
+func appendLCSDiff(ops []diffOp, a, b []string) []diffOp {
+	switch {
+	case len(a) == 0:
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	case len(b) == 0:
+		for _, l := range a {
+			ops = append(ops, diffOp{'-', l})
+		}
+		return ops
+	case len(a) == 1:
+		for j, l := range b {
+			if l == a[0] {
+				for _, l := range b[:j] {
+					ops = append(ops, diffOp{'+', l})
+				}
+				ops = append(ops, diffOp{' ', l})
+				for _, l := range b[j+1:] {
+					ops = append(ops, diffOp{'+', l})
+				}
+				return ops
+			}
+		}
+		ops = append(ops, diffOp{'-', a[0]})
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+
+	// split b at the point where LCS of a[:mid] and b[:k] plus LCS of a[mid:] and b[k:] is the longest
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b, false)
+	backward := lcsLengths(a[mid:], b, true)
+	split, longest := 0, -1
+	for k := 0; k <= len(b); k++ {
+		if l := forward[k] + backward[len(b)-k]; l > longest {
+			split, longest = k, l
+		}
+	}
+	ops = appendLCSDiff(ops, a[:mid], b[:split])
+	return appendLCSDiff(ops, a[mid:], b[split:])
+}
+
+// lcsLengths returns the lengths of LCS of a and each prefix of b, b[:k] at index k.
+// If reverse is true, it is computed from the end of a and b instead, then index k is for the suffix b[len(b)-k:].
+func lcsLengths(a, b []string, reverse bool) []int {
+	at := func(s []string, i int) string {
+		if reverse {
+			return s[len(s)-1-i]
+		}
+		return s[i]
+	}
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if at(a, i) == at(b, j) {
+				cur[j+1] = prev[j] + 1
+			} else if prev[j+1] >= cur[j] {
+				cur[j+1] = prev[j+1]
+			} else {
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// unifiedDiff returns the difference between old and new in unified format.
+// It returns empty string if there is no difference.
+func unifiedDiff(oldName, newName string, old, new []byte) string {
+	ops := diffLines(splitLines(string(old)), splitLines(string(new)))
+
+	var (
+		b       strings.Builder
+		changed []int // indexes of changed ops
+	)
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for n := 0; n < len(changed); {
+		// determine the range of the hunk, merging changes close to each other
+		start := changed[n] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changed[n]
+		for n < len(changed) && changed[n] <= end+2*diffContext {
+			end = changed[n]
+			n++
+		}
+		end += diffContext + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		// line numbers(1-based) where the hunk starts
+		oldLine, newLine := 1, 1
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				oldLine++
+			}
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+		var oldCount, newCount int
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldLine--
+		}
+		if newCount == 0 {
+			newLine--
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	new := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+	want := `--- a/x.go
++++ b/x.go
+@@ -2,9 +2,10 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
+ j
++k
+`
+	if got := unifiedDiff("a/x.go", "b/x.go", []byte(old), []byte(new)); got != want {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+	if got := unifiedDiff("a/x.go", "b/x.go", []byte(old), []byte(old)); got != "" {
+		t.Errorf("unexpected diff of same contents:\n%s", got)
+	}
+}
+
+// lcsLength computes the length of LCS with the full table, as reference.
+func lcsLength(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				table[i][j] = table[i+1][j+1] + 1
+			case table[i+1][j] >= table[i][j+1]:
+				table[i][j] = table[i+1][j]
+			default:
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+	return table[0][0]
+}
+
+func TestDiffLines(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = fmt.Sprintf("%d\n", rnd.Intn(5))
+		}
+		return lines
+	}
+	for n := 0; n < 500; n++ {
+		a, b := randomLines(), randomLines()
+		ops := diffLines(a, b)
+
+		var gotA, gotB []string
+		var common int
+		for _, op := range ops {
+			if op.kind != '+' {
+				gotA = append(gotA, op.line)
+			}
+			if op.kind != '-' {
+				gotB = append(gotB, op.line)
+			}
+			if op.kind == ' ' {
+				common++
+			}
+		}
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("edit script does not reproduce the inputs: %q, %q", a, b)
+		}
+		if want := lcsLength(a, b); common != want {
+			t.Fatalf("edit script is not the shortest: %d common lines, want %d: %q, %q", common, want, a, b)
+		}
+	}
+}
+
+func TestDiffLinesLargeFile(t *testing.T) {
+	const n = 10000
+	a, b := make([]string, n), make([]string, n)
+	for i := range a {
+		a[i] = fmt.Sprintf("old %d\n", i)
+		b[i] = fmt.Sprintf("new %d\n", i)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	ops := diffLines(a, b)
+	runtime.ReadMemStats(&after)
+
+	if len(ops) != 2*n {
+		t.Fatalf("unexpected length of edit script: %d", len(ops))
+	}
+	// the full LCS table would take 800MB
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<20 {
+		t.Errorf("too much memory allocated: %d bytes", allocated)
+	}
+}
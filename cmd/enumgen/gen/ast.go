@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strconv"
 )
 
 func importSpecs(s []*ast.ImportSpec) *ast.GenDecl {
@@ -17,24 +18,12 @@ func importSpecs(s []*ast.ImportSpec) *ast.GenDecl {
 	return decl
 }
 
-func visitorSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, visitorReturnIdent ast.Expr) *ast.TypeSpec {
+func visitorSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, sig *signature, et *enumTypes) *ast.TypeSpec {
 	// ExampleVisitor interface {
 	// 	VisitA(e A)
 	// 	VisitB(e B)
 	// }
 
-	// Visit(e A) visitReturnIdent
-	var results *ast.FieldList
-	if visitorReturnIdent != nil {
-		results = &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Type: visitorReturnIdent,
-				},
-			},
-		}
-	}
-
 	methodList := make([]*ast.Field, 0, len(members))
 	for _, m := range members {
 		methodList = append(methodList, &ast.Field{
@@ -42,23 +31,20 @@ func visitorSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, visi
 				ast.NewIdent(r.visitMethodName(enumIdent, m.String())),
 			},
 			Type: &ast.FuncType{
-				Params: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Names: []*ast.Ident{
-								ast.NewIdent("e"),
-							},
-							Type: m,
-						},
+				Params: sig.params(&ast.Field{
+					Names: []*ast.Ident{
+						ast.NewIdent("e"),
 					},
-				},
-				Results: results,
+					Type: et.member(m),
+				}),
+				Results: sig.resultList(),
 			},
 		})
 	}
 
 	return &ast.TypeSpec{
-		Name: ast.NewIdent(r.visitorTypeName(enumIdent)),
+		Name:       ast.NewIdent(r.visitorTypeName(enumIdent)),
+		TypeParams: et.params(),
 		Type: &ast.InterfaceType{
 			Methods: &ast.FieldList{
 				List: methodList,
@@ -67,147 +53,186 @@ func visitorSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, visi
 	}
 }
 
-func enumSpec(r *namingRegistry, enumIdent string, visitorReturnIdent ast.Expr) *ast.TypeSpec {
+func enumSpec(r *namingRegistry, enumIdent string, sig *signature, et *enumTypes) *ast.TypeSpec {
 	// ExampleEnum interface {
 	// 	Accept(v ExampleVisitor)
 	// }
 
-	// Accept(v Visitor) visitorReturnIdent
-	var results *ast.FieldList
-	if visitorReturnIdent != nil {
-		results = &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Type: visitorReturnIdent,
-				},
+	methods := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent(r.acceptMethodName(enumIdent)),
 			},
-		}
+			Type: &ast.FuncType{
+				Params: sig.params(&ast.Field{
+					Names: []*ast.Ident{
+						ast.NewIdent("v"),
+					},
+					Type: et.instance(r.visitorTypeName(enumIdent)),
+				}),
+				Results: sig.resultList(),
+			},
+		},
+	}
+	if r.hasKind(enumIdent) {
+		// 	Kind() ExampleKind
+		methods = append(methods, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(r.kindMethodName(enumIdent)),
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: fieldList(
+					field("", ast.NewIdent(r.kindTypeName(enumIdent))),
+				),
+			},
+		})
+	}
+	if r.isSealed(enumIdent) {
+		// 	__ExampleEnum()
+		methods = append(methods, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(r.sealedMethodName(enumIdent)),
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+		})
 	}
 
 	return &ast.TypeSpec{
-		Name: ast.NewIdent(fmt.Sprintf("%sEnum", enumIdent)),
+		Name:       ast.NewIdent(fmt.Sprintf("%sEnum", enumIdent)),
+		TypeParams: et.params(),
 		Type: &ast.InterfaceType{
 			Methods: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent(r.acceptMethodName(enumIdent)),
-						},
-						Type: &ast.FuncType{
-							Params: &ast.FieldList{
-								List: []*ast.Field{
-									{
-										Names: []*ast.Ident{
-											ast.NewIdent("v"),
-										},
-										Type: ast.NewIdent(r.visitorTypeName(enumIdent)),
-									},
-								},
-							},
-							Results: results,
-						},
-					},
-				},
+				List: methods,
 			},
 		},
 	}
 }
 
-func acceptImpl(r *namingRegistry, enumIdent string, member *ast.Ident, visitorReturnIdent ast.Expr) *ast.FuncDecl {
+func acceptImpl(r *namingRegistry, enumIdent string, member, via *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (e A) Accept(v ExampleVisitor) {
+	// 	v.VisitA(e)
+	// }
+	// or, if A is the member of nested enum Sub(via)
+	// func (e A) Accept(v ExampleVisitor) {
+	// 	v.VisitSub(e)
+	// }
+	// or, if the visitor returns values
+	// func (e A) Accept(v ExampleVisitor) R {
+	// 	return v.VisitA(e)
+	// }
+
 	var (
-		stmts   []ast.Stmt
-		results *ast.FieldList
 		enumVal = ast.NewIdent("e")
 		visitor = ast.NewIdent("v")
 	)
 
-	if visitorReturnIdent != nil {
-		// func (e A) Accept(v ExampleVisitor) visitorReturnIdent {
-		// 	return v.VisitA(e)
-		// }
-		results = &ast.FieldList{
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
 			List: []*ast.Field{
 				{
-					Type: visitorReturnIdent,
-				},
-			},
-		}
-		stmts = []ast.Stmt{
-			&ast.ReturnStmt{
-				Results: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   visitor,
-							Sel: ast.NewIdent(r.visitMethodName(enumIdent, member.String())),
-						},
-						Args: []ast.Expr{
-							enumVal,
-						},
+					Names: []*ast.Ident{
+						enumVal,
 					},
+					Type: et.member(member),
 				},
 			},
-		}
-	} else {
-		// func (e A) Accept(v ExampleVisitor) {
-		// 	v.VisitA(e)
-		// }
-		stmts = []ast.Stmt{
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
+		},
+		Name: ast.NewIdent(r.acceptMethodName(enumIdent)),
+		Type: &ast.FuncType{
+			Params: sig.params(&ast.Field{
+				Names: []*ast.Ident{
+					visitor,
+				},
+				Type: et.instance(r.visitorTypeName(enumIdent)),
+			}),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				sig.callStmt(&ast.CallExpr{
 					Fun: &ast.SelectorExpr{
 						X:   visitor,
-						Sel: ast.NewIdent(r.visitMethodName(enumIdent, member.String())),
-					},
-					Args: []ast.Expr{
-						enumVal,
+						Sel: ast.NewIdent(r.visitMethodName(enumIdent, via.String())),
 					},
-				},
+					Args: sig.callArgs(enumVal),
+				}),
 			},
-		}
+		},
 	}
+}
 
+func sealedImpl(r *namingRegistry, enumIdent string, member *ast.Ident, et *enumTypes) *ast.FuncDecl {
+	// func (A) __ExampleEnum() {}
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
 				{
-					Names: []*ast.Ident{
-						enumVal,
-					},
-					Type: member,
+					Type: et.member(member),
 				},
 			},
 		},
-		Name: ast.NewIdent(r.acceptMethodName(enumIdent)),
+		Name: ast.NewIdent(r.sealedMethodName(enumIdent)),
 		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							visitor,
-						},
-						Type: ast.NewIdent(r.visitorTypeName(enumIdent)),
-					},
-				},
-			},
-			Results: results,
-		},
-		Body: &ast.BlockStmt{
-			List: stmts,
+			Params: &ast.FieldList{},
 		},
+		Body: &ast.BlockStmt{},
+	}
+}
+
+// zero values of the members in declaration order, e.g. `A{}` or `&A{}`
+func zeroValues(members []*ast.Ident, et *enumTypes) []ast.Expr {
+	values := make([]ast.Expr, 0, len(members))
+	for _, m := range members {
+		values = append(values, et.zero(m))
 	}
+	return values
 }
 
-func typeCheckDecl(enumIdent string, members []*ast.Ident) *ast.GenDecl {
+func typeCheckDecl(enumIdent string, members []*ast.Ident, et *enumTypes) ast.Decl {
 	// var _ = []ExampleEnum{
 	// 	A{},
 	// 	B{},
 	// }
+	// or, if members are referred by pointer
+	// var _ = []ExampleEnum{
+	// 	&A{},
+	// 	&B{},
+	// }
+	// or, if the enum is generic
+	// func _[T any]() {
+	// 	_ = []ExampleEnum[T]{A[T]{}, B[T]{}}
+	// }
 
-	enumMembers := make([]ast.Expr, 0, len(members))
-	for _, m := range members {
-		enumMembers = append(enumMembers, &ast.CompositeLit{
-			Type: m,
-		})
+	list := &ast.CompositeLit{
+		Type: &ast.ArrayType{
+			Elt: et.instance(fmt.Sprintf("%sEnum", enumIdent)),
+		},
+		Elts: zeroValues(members, et),
+	}
+	if et.generic() {
+		return &ast.FuncDecl{
+			Name: ast.NewIdent("_"),
+			Type: &ast.FuncType{
+				TypeParams: et.params(),
+				Params:     &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("_"),
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							list,
+						},
+					},
+				},
+			},
+		}
 	}
 
 	return &ast.GenDecl{
@@ -218,35 +243,19 @@ func typeCheckDecl(enumIdent string, members []*ast.Ident) *ast.GenDecl {
 					ast.NewIdent("_"),
 				},
 				Values: []ast.Expr{
-					&ast.CompositeLit{
-						Type: &ast.ArrayType{
-							Elt: ast.NewIdent(fmt.Sprintf("%sEnum", enumIdent)),
-						},
-						Elts: enumMembers,
-					},
+					list,
 				},
 			},
 		},
 	}
 }
 
-func visitorImplSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, visitorReturnIdent ast.Expr) *ast.GenDecl {
+func visitorImplSpec(r *namingRegistry, enumIdent string, members []*ast.Ident, sig *signature, et *enumTypes) *ast.GenDecl {
 	// type __ExampleVisitor struct {
 	// 	__VisitA func(A) error
 	// 	__VisitB func(B) error
 	// }
 
-	var results *ast.FieldList
-	if visitorReturnIdent != nil {
-		results = &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Type: visitorReturnIdent,
-				},
-			},
-		}
-	}
-
 	var fields []*ast.Field
 	for _, m := range members {
 		fields = append(fields,
@@ -255,14 +264,8 @@ func visitorImplSpec(r *namingRegistry, enumIdent string, members []*ast.Ident,
 					ast.NewIdent(fmt.Sprintf("__%s", r.visitMethodName(enumIdent, m.String()))),
 				},
 				Type: &ast.FuncType{
-					Params: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Type: m,
-							},
-						},
-					},
-					Results: results,
+					Params:  sig.paramTypes(et.member(m)),
+					Results: sig.resultList(),
 				},
 			})
 	}
@@ -271,7 +274,8 @@ func visitorImplSpec(r *namingRegistry, enumIdent string, members []*ast.Ident,
 		Tok: token.TYPE,
 		Specs: []ast.Spec{
 			&ast.TypeSpec{
-				Name: ast.NewIdent(fmt.Sprintf("__%s", r.visitorTypeName(enumIdent))),
+				Name:       ast.NewIdent(fmt.Sprintf("__%s", r.visitorTypeName(enumIdent))),
+				TypeParams: et.params(),
 				Type: &ast.StructType{
 					Fields: &ast.FieldList{
 						List: fields,
@@ -282,7 +286,7 @@ func visitorImplSpec(r *namingRegistry, enumIdent string, members []*ast.Ident,
 	}
 }
 
-func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName string, members []*ast.Ident, visitorReturnIdent ast.Expr) *ast.FuncDecl {
+func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName string, members []*ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
 	// func NewExampleVisitor(
 	// 	__VisitA func(e A) error,
 	// 	__VisitB func(e B) error,
@@ -295,24 +299,13 @@ func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName
 
 	var (
 		visitorFactory  = ast.NewIdent(visitorFactoryName)
-		visitorType     = ast.NewIdent(registry.visitorTypeName(enumIdent))
-		visitorImplType = ast.NewIdent(fmt.Sprintf("__%s", registry.visitorTypeName(enumIdent)))
-		visitorReturns  *ast.FieldList
+		visitorType     = et.instance(registry.visitorTypeName(enumIdent))
+		visitorImplType = et.instance(fmt.Sprintf("__%s", registry.visitorTypeName(enumIdent)))
 
 		args        []*ast.Field
 		compositeKv []ast.Expr
 	)
 
-	if visitorReturnIdent != nil {
-		visitorReturns = &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Type: visitorReturnIdent,
-				},
-			},
-		}
-	}
-
 	for _, m := range members {
 		fieldName := ast.NewIdent(fmt.Sprintf("__%s", registry.visitMethodName(enumIdent, m.String())))
 		args = append(args, &ast.Field{
@@ -320,17 +313,13 @@ func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName
 				fieldName,
 			},
 			Type: &ast.FuncType{
-				Params: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Names: []*ast.Ident{
-								ast.NewIdent("e"),
-							},
-							Type: m,
-						},
+				Params: sig.params(&ast.Field{
+					Names: []*ast.Ident{
+						ast.NewIdent("e"),
 					},
-				},
-				Results: visitorReturns,
+					Type: et.member(m),
+				}),
+				Results: sig.resultList(),
 			},
 		})
 		compositeKv = append(compositeKv, &ast.KeyValueExpr{
@@ -342,6 +331,7 @@ func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName
 	return &ast.FuncDecl{
 		Name: visitorFactory,
 		Type: &ast.FuncType{
+			TypeParams: et.params(),
 			Params: &ast.FieldList{
 				List: args,
 			},
@@ -371,90 +361,188 @@ func visitorFactoryImpl(registry *namingRegistry, enumIdent, visitorFactoryName
 	}
 }
 
-func visitorImpl(registry *namingRegistry, enumIdent string, member *ast.Ident, visitorReturnIdent ast.Expr) *ast.FuncDecl {
+func visitorImpl(registry *namingRegistry, enumIdent string, member *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
 	// func (v __ExampleVisitor) VisitA(e A) error {
 	// 	return v.__VisitA(e)
 	// }
 
 	var (
-		stmts   []ast.Stmt
-		results *ast.FieldList
 		enumVal = ast.NewIdent("e")
 		visitor = ast.NewIdent("v")
 	)
 
-	if visitorReturnIdent != nil {
-		// func (v __ExampleVisitor) VisitA(e A) visitorReturnIdent {
-		// 	return v.__VisitA(e)
-		// }
-		results = &ast.FieldList{
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
 			List: []*ast.Field{
 				{
-					Type: visitorReturnIdent,
+					Names: []*ast.Ident{
+						visitor,
+					},
+					Type: et.instance(fmt.Sprintf("__%s", registry.visitorTypeName(enumIdent))),
 				},
 			},
-		}
-		stmts = []ast.Stmt{
-			&ast.ReturnStmt{
-				Results: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   visitor,
-							Sel: ast.NewIdent(fmt.Sprintf("__%s", registry.visitMethodName(enumIdent, member.String()))),
-						},
-						Args: []ast.Expr{
-							enumVal,
-						},
+		},
+		Name: ast.NewIdent(registry.visitMethodName(enumIdent, member.String())),
+		Type: &ast.FuncType{
+			Params: sig.params(&ast.Field{
+				Names: []*ast.Ident{
+					enumVal,
+				},
+				Type: et.member(member),
+			}),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				sig.callStmt(&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   visitor,
+						Sel: ast.NewIdent(fmt.Sprintf("__%s", registry.visitMethodName(enumIdent, member.String()))),
 					},
+					Args: sig.callArgs(enumVal),
+				}),
+			},
+		},
+	}
+}
+
+func matchFuncDecl(r *namingRegistry, enumIdent, matchFuncName, fmtPkg string, members []*ast.Ident, leaves []enumLeaf, et *enumTypes) *ast.FuncDecl {
+	// func MatchExample[R any](e ExampleEnum, visitA func(e A) R, visitB func(e B) R) R {
+	// 	switch e := e.(type) {
+	// 	case A:
+	// 		return visitA(e)
+	// 	case B:
+	// 		return visitB(e)
+	// 	}
+	// 	panic(fmt.Sprintf("unexpected ExampleEnum: %T", e))
+	// }
+	// The switch is on the leaves, because nested enum(e.g. `interface{ enum.SubEnumOf[Example] }`) matches any value.
+	// Leaves of nested enum are passed to the callback of the nested enum.
+
+	var (
+		enumVal    = ast.NewIdent("e")
+		resultType = ast.NewIdent(et.unusedName("R"))
+		enumType   = fmt.Sprintf("%sEnum", enumIdent)
+
+		params = []*ast.Field{
+			{
+				Names: []*ast.Ident{
+					enumVal,
 				},
+				Type: et.instance(enumType),
 			},
 		}
-	} else {
-		// func (v __ExampleVisitor) VisitA(e A) {
-		// 	v.__VisitA(e)
-		// }
-		stmts = []ast.Stmt{
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   visitor,
-						Sel: ast.NewIdent(registry.visitMethodName(enumIdent, member.String())),
+		clauses []ast.Stmt
+	)
+
+	for _, m := range members {
+		callback := ast.NewIdent(r.matchCallbackName(enumIdent, m.String()))
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{
+				callback,
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								ast.NewIdent("e"),
+							},
+							Type: et.member(m),
+						},
 					},
-					Args: []ast.Expr{
-						enumVal,
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: resultType,
+						},
 					},
 				},
 			},
-		}
+		})
 	}
-
-	return &ast.FuncDecl{
-		Recv: &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Names: []*ast.Ident{
-						visitor,
+	for _, l := range leaves {
+		m := l.def.ident
+		callback := ast.NewIdent(r.matchCallbackName(enumIdent, l.via.Name))
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{
+				et.member(m),
+			},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: callback,
+							Args: []ast.Expr{
+								enumVal,
+							},
+						},
 					},
-					Type: ast.NewIdent(fmt.Sprintf("__%s", registry.visitorTypeName(enumIdent))),
 				},
 			},
-		},
-		Name: ast.NewIdent(registry.visitMethodName(enumIdent, member.String())),
+		})
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(matchFuncName),
 		Type: &ast.FuncType{
+			TypeParams: et.params(&ast.Field{
+				Names: []*ast.Ident{
+					resultType,
+				},
+				Type: ast.NewIdent("any"),
+			}),
 			Params: &ast.FieldList{
+				List: params,
+			},
+			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Names: []*ast.Ident{
-							enumVal,
-						},
-						Type: member,
+						Type: resultType,
 					},
 				},
 			},
-			Results: results,
 		},
 		Body: &ast.BlockStmt{
-			List: stmts,
+			List: []ast.Stmt{
+				&ast.TypeSwitchStmt{
+					Assign: &ast.AssignStmt{
+						Lhs: []ast.Expr{
+							enumVal,
+						},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.TypeAssertExpr{
+								X: enumVal,
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("panic"),
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent(fmtPkg),
+									Sel: ast.NewIdent("Sprintf"),
+								},
+								Args: []ast.Expr{
+									&ast.BasicLit{
+										Kind:  token.STRING,
+										Value: strconv.Quote(fmt.Sprintf("unexpected %s: %%T", enumType)),
+									},
+									enumVal,
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
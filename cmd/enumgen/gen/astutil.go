@@ -0,0 +1,101 @@
+package gen
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Shorthands to build the statements of generated function bodies.
+
+func selector(x ast.Expr, sel string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   x,
+		Sel: ast.NewIdent(sel),
+	}
+}
+
+func call(fun ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:  fun,
+		Args: args,
+	}
+}
+
+func stringLit(s string) *ast.BasicLit {
+	return &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: strconv.Quote(s),
+	}
+}
+
+func field(name string, t ast.Expr) *ast.Field {
+	f := &ast.Field{
+		Type: t,
+	}
+	if name != "" {
+		f.Names = []*ast.Ident{
+			ast.NewIdent(name),
+		}
+	}
+	return f
+}
+
+func fieldList(fields ...*ast.Field) *ast.FieldList {
+	return &ast.FieldList{
+		List: fields,
+	}
+}
+
+func returnStmt(results ...ast.Expr) *ast.ReturnStmt {
+	return &ast.ReturnStmt{
+		Results: results,
+	}
+}
+
+func define(lhs []ast.Expr, rhs ...ast.Expr) *ast.AssignStmt {
+	return &ast.AssignStmt{
+		Lhs: lhs,
+		Tok: token.DEFINE,
+		Rhs: rhs,
+	}
+}
+
+func varDecl(name string, t ast.Expr) *ast.DeclStmt {
+	return &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent(name),
+					},
+					Type: t,
+				},
+			},
+		},
+	}
+}
+
+//	if err := init; err != nil {
+//		return results...
+//	}
+func ifErrReturn(init ast.Expr, results ...ast.Expr) *ast.IfStmt {
+	err := ast.NewIdent("err")
+	stmt := &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  err,
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(results...),
+			},
+		},
+	}
+	if init != nil {
+		stmt.Init = define([]ast.Expr{err}, init)
+	}
+	return stmt
+}
@@ -0,0 +1,240 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Typed in-process bus delivering members to subscribers, backed by enum.Bus.
+
+func busSpec(r *namingRegistry, enumIdent, enumPkg string, et *enumTypes) *ast.GenDecl {
+	// type ExampleBus struct {
+	// 	bus *enum.Bus
+	// }
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       ast.NewIdent(r.busTypeName(enumIdent)),
+				TypeParams: et.params(),
+				Type: &ast.StructType{
+					Fields: fieldList(
+						field("bus", &ast.StarExpr{
+							X: selector(ast.NewIdent(enumPkg), "Bus"),
+						}),
+					),
+				},
+			},
+		},
+	}
+}
+
+func newBusFunc(r *namingRegistry, enumIdent, enumPkg string, et *enumTypes) *ast.FuncDecl {
+	// func NewExampleBus(opts ...enum.BusOption) *ExampleBus {
+	// 	return &ExampleBus{bus: enum.NewBus(opts...)}
+	// }
+
+	busType := et.instance(r.busTypeName(enumIdent))
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("New" + r.busTypeName(enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params: fieldList(
+				field("opts", &ast.Ellipsis{
+					Elt: selector(ast.NewIdent(enumPkg), "BusOption"),
+				}),
+			),
+			Results: fieldList(
+				field("", &ast.StarExpr{
+					X: busType,
+				}),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.UnaryExpr{
+					Op: token.AND,
+					X: &ast.CompositeLit{
+						Type: busType,
+						Elts: []ast.Expr{
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("bus"),
+								Value: &ast.CallExpr{
+									Fun:      selector(ast.NewIdent(enumPkg), "NewBus"),
+									Args:     []ast.Expr{ast.NewIdent("opts")},
+									Ellipsis: 1,
+								},
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+func busRecv(r *namingRegistry, enumIdent string, et *enumTypes) *ast.FieldList {
+	return fieldList(
+		field("b", &ast.StarExpr{
+			X: et.instance(r.busTypeName(enumIdent)),
+		}),
+	)
+}
+
+// Members are keyed by the member name(see memberName) in the bus.
+// If the visitor takes context, the subscriber receives the context passed to Publish.
+func subscribeFunc(r *namingRegistry, enumIdent, contextPkg string, member *ast.Ident, name memberName, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (b *ExampleBus) SubscribeA(fn func(e A) error) (unsubscribe func()) {
+	// 	return b.bus.Subscribe("A", func(_ context.Context, v any) error {
+	// 		return fn(v.(A))
+	// 	})
+	// }
+
+	var (
+		ctx      = ast.NewIdent("_")
+		params   []*ast.Field
+		callArgs []ast.Expr
+		value    = &ast.TypeAssertExpr{
+			X:    ast.NewIdent("v"),
+			Type: et.member(member),
+		}
+	)
+	if sig.context != nil {
+		ctx = ast.NewIdent("ctx")
+		params = append(params, field("ctx", sig.context))
+		callArgs = append(callArgs, ctx)
+	}
+	params = append(params, field("e", et.member(member)))
+	callArgs = append(callArgs, value)
+
+	handlerType := &ast.FuncType{
+		Params: fieldList(params...),
+		Results: fieldList(
+			field("", ast.NewIdent("error")),
+		),
+	}
+	return &ast.FuncDecl{
+		Recv: busRecv(r, enumIdent, et),
+		Name: ast.NewIdent("Subscribe" + member.Name),
+		Type: &ast.FuncType{
+			Params: fieldList(
+				field("fn", handlerType),
+			),
+			Results: fieldList(
+				field("unsubscribe", &ast.FuncType{
+					Params: &ast.FieldList{},
+				}),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(call(
+					selector(selector(ast.NewIdent("b"), "bus"), "Subscribe"),
+					stringLit(name.name),
+					&ast.FuncLit{
+						Type: &ast.FuncType{
+							Params: fieldList(
+								field(ctx.Name, selector(ast.NewIdent(contextPkg), "Context")),
+								field("v", ast.NewIdent("any")),
+							),
+							Results: fieldList(
+								field("", ast.NewIdent("error")),
+							),
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								returnStmt(call(ast.NewIdent("fn"), callArgs...)),
+							},
+						},
+					},
+				)),
+			},
+		},
+	}
+}
+
+func publishFunc(r *namingRegistry, enumIdent, contextPkg, fmtPkg string, members []*ast.Ident, names []memberName, et *enumTypes) *ast.FuncDecl {
+	// func (b *ExampleBus) Publish(ctx context.Context, e ExampleEnum) error {
+	// 	switch e.(type) {
+	// 	case A:
+	// 		return b.bus.Publish(ctx, "A", e)
+	// 	...
+	// 	}
+	// 	return fmt.Errorf("unexpected ExampleEnum: %T", e)
+	// }
+
+	var (
+		enumVal  = ast.NewIdent("e")
+		enumType = fmt.Sprintf("%sEnum", enumIdent)
+
+		clauses []ast.Stmt
+	)
+	for i, m := range members {
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{
+				et.member(m),
+			},
+			Body: []ast.Stmt{
+				returnStmt(call(
+					selector(selector(ast.NewIdent("b"), "bus"), "Publish"),
+					ast.NewIdent("ctx"),
+					stringLit(names[i].name),
+					enumVal,
+				)),
+			},
+		})
+	}
+
+	return &ast.FuncDecl{
+		Recv: busRecv(r, enumIdent, et),
+		Name: ast.NewIdent("Publish"),
+		Type: &ast.FuncType{
+			Params: fieldList(
+				field("ctx", selector(ast.NewIdent(contextPkg), "Context")),
+				field("e", et.instance(enumType)),
+			),
+			Results: fieldList(
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.TypeSwitchStmt{
+					Assign: &ast.ExprStmt{
+						X: &ast.TypeAssertExpr{
+							X: enumVal,
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				returnStmt(
+					call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("unexpected %s: %%T", enumType)), enumVal),
+				),
+			},
+		},
+	}
+}
+
+func closeBusFunc(r *namingRegistry, enumIdent string, et *enumTypes) *ast.FuncDecl {
+	// func (b *ExampleBus) Close() error {
+	// 	return b.bus.Close()
+	// }
+	return &ast.FuncDecl{
+		Recv: busRecv(r, enumIdent, et),
+		Name: ast.NewIdent("Close"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(call(selector(selector(ast.NewIdent("b"), "bus"), "Close"))),
+			},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Construction of the member from its name, for the decoders of any codec.
+
+func factoriesVarName(enumIdent string) string {
+	return fmt.Sprintf("__%sFactories", enumIdent)
+}
+
+func factoryFuncName(enumIdent, memberName string) string {
+	return fmt.Sprintf("__new%s%s", enumIdent, memberName)
+}
+
+func factoryFunc(enumIdent string, member *ast.Ident, et *enumTypes) *ast.FuncDecl {
+	// func __newExampleA() Example {
+	// 	return &A{} // members are always referred by pointer
+	// }
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(factoryFuncName(enumIdent, member.Name)),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", ast.NewIdent(enumIdent)),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(et.zero(member)),
+			},
+		},
+	}
+}
+
+func factoriesDecl(enumIdent string, members []*ast.Ident, names []memberName) *ast.GenDecl {
+	// var __ExampleFactories = map[string]func() Example{"A": __newExampleA, "a": __newExampleA, "B": __newExampleB}
+	// aliases are registered as well
+
+	var elts []ast.Expr
+	for i, m := range members {
+		for _, name := range append([]string{names[i].name}, names[i].aliases...) {
+			elts = append(elts, &ast.KeyValueExpr{
+				Key:   stringLit(name),
+				Value: ast.NewIdent(factoryFuncName(enumIdent, m.Name)),
+			})
+		}
+	}
+
+	return &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent(factoriesVarName(enumIdent)),
+				},
+				Values: []ast.Expr{
+					&ast.CompositeLit{
+						Type: &ast.MapType{
+							Key: ast.NewIdent("string"),
+							Value: &ast.FuncType{
+								Params: &ast.FieldList{},
+								Results: fieldList(
+									field("", ast.NewIdent(enumIdent)),
+								),
+							},
+						},
+						Elts: elts,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newByNameFunc(enumIdent, fmtPkg string) *ast.FuncDecl {
+	// func NewExampleByName(name string) (Example, error) {
+	// 	f, ok := __ExampleFactories[name]
+	// 	if !ok {
+	// 		return nil, fmt.Errorf("unknown name of Example: %q", name)
+	// 	}
+	// 	return f(), nil
+	// }
+
+	var (
+		name    = ast.NewIdent("name")
+		f       = ast.NewIdent("f")
+		ok      = ast.NewIdent("ok")
+		nilExpr = ast.NewIdent("nil")
+	)
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("New%sByName", enumIdent)),
+		Type: &ast.FuncType{
+			Params: fieldList(
+				field("name", ast.NewIdent("string")),
+			),
+			Results: fieldList(
+				field("", ast.NewIdent(enumIdent)),
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				define([]ast.Expr{f, ok}, &ast.IndexExpr{
+					X:     ast.NewIdent(factoriesVarName(enumIdent)),
+					Index: name,
+				}),
+				&ast.IfStmt{
+					Cond: &ast.UnaryExpr{
+						Op: token.NOT,
+						X:  ok,
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							returnStmt(
+								nilExpr,
+								call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("unknown name of %s: %%q", enumIdent)), name),
+							),
+						},
+					},
+				},
+				returnStmt(call(f), nilExpr),
+			},
+		},
+	}
+}
+
+func namesFunc(enumIdent string, names []memberName) *ast.FuncDecl {
+	// func ExampleNames() []string {
+	// 	return []string{"A", "B"}
+	// }
+
+	elts := make([]ast.Expr, 0, len(names))
+	for _, n := range names {
+		elts = append(elts, stringLit(n.name))
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("%sNames", enumIdent)),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", &ast.ArrayType{
+					Elt: ast.NewIdent("string"),
+				}),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.CompositeLit{
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent("string"),
+					},
+					Elts: elts,
+				}),
+			},
+		},
+	}
+}
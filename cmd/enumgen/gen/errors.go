@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrNoEnum is returned when no enum is found in the target package.
+var ErrNoEnum = errors.New("target type not found")
+
+// Error is an error about the source code of the target package.
+type Error struct {
+	Pos token.Position // position of the cause, may be invalid if unknown
+	Msg string
+}
+
+func newError(pos token.Position, format string, args ...any) *Error {
+	return &Error{
+		Pos: pos,
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+	}
+	return e.Msg
+}
+
+// PackageError is an error occurred while generating the code of the package.
+type PackageError struct {
+	PkgPath string
+	Err     error
+}
+
+// Error implements error.
+func (e *PackageError) Error() string {
+	return fmt.Sprintf("%s: %s", e.PkgPath, e.Err)
+}
+
+func (e *PackageError) Unwrap() error {
+	return e.Err
+}
+
+// Convert error reported by go/packages, whose position is formatted as "file:line:col".
+func packageError(e packages.Error) *Error {
+	var pos token.Position
+	s := e.Pos
+	for _, p := range []*int{&pos.Column, &pos.Line} {
+		i := strings.LastIndex(s, ":")
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			break
+		}
+		*p = n
+		s = s[:i]
+	}
+	if pos.Line == 0 {
+		// "file:line" form
+		pos.Line, pos.Column = pos.Column, 0
+	}
+	if pos.Line > 0 {
+		pos.Filename = s
+	}
+	return &Error{
+		Pos: pos,
+		Msg: e.Msg,
+	}
+}
+
+// errorList collects errors occurred in pipeline stages.
+type errorList struct {
+	mu   sync.Mutex
+	errs []*Error
+}
+
+func (l *errorList) add(err *Error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+}
+
+// err returns collected errors sorted by position.
+func (l *errorList) err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(l.errs, func(i, j int) bool {
+		pi, pj := l.errs[i].Pos, l.errs[j].Pos
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+	errs := make([]error, 0, len(l.errs))
+	for _, err := range l.errs {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
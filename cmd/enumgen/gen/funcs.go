@@ -0,0 +1,178 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Visitor built from handler funcs with named fields, falling back to the default handler.
+
+func visitorFuncsHandlerName(member string) string {
+	return fmt.Sprintf("On%s", member)
+}
+
+func visitorFuncsSpec(r *namingRegistry, enumIdent, funcsTypeName string, members []*ast.Ident, sig *signature, et *enumTypes) *ast.GenDecl {
+	// type ExampleVisitorFuncs struct {
+	// 	OnA     func(e A) R
+	// 	OnB     func(e B) R
+	// 	Default func(e ExampleEnum) R
+	// }
+
+	fields := make([]*ast.Field, 0, len(members)+1)
+	for _, m := range members {
+		fields = append(fields, field(visitorFuncsHandlerName(m.Name), &ast.FuncType{
+			Params:  sig.params(field("e", et.member(m))),
+			Results: sig.resultList(),
+		}))
+	}
+	fields = append(fields, field("Default", &ast.FuncType{
+		Params:  sig.params(field("e", et.instance(fmt.Sprintf("%sEnum", enumIdent)))),
+		Results: sig.resultList(),
+	}))
+
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       ast.NewIdent(funcsTypeName),
+				TypeParams: et.params(),
+				Type: &ast.StructType{
+					Fields: fieldList(fields...),
+				},
+			},
+			&ast.TypeSpec{
+				// type __ExampleVisitorFuncs ExampleVisitorFuncs
+				Name:       ast.NewIdent("__" + funcsTypeName),
+				TypeParams: et.params(),
+				Type:       et.instance(funcsTypeName),
+			},
+		},
+	}
+}
+
+func visitorFuncsBuild(r *namingRegistry, enumIdent, funcsTypeName, fmtPkg string, members []*ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (f ExampleVisitorFuncs) Build() (ExampleVisitor, error) {
+	// 	if f.OnA == nil {
+	// 		if f.Default == nil {
+	// 			return nil, fmt.Errorf("ExampleVisitorFuncs: no handler for A")
+	// 		}
+	// 		f.OnA = func(e A) R {
+	// 			return f.Default(e)
+	// 		}
+	// 	}
+	// 	...
+	// 	return __ExampleVisitorFuncs(f), nil
+	// }
+
+	var (
+		f       = ast.NewIdent("f")
+		nilExpr = ast.NewIdent("nil")
+		enum    = et.instance(fmt.Sprintf("%sEnum", enumIdent))
+
+		stmts []ast.Stmt
+	)
+	for _, m := range members {
+		handler := selector(f, visitorFuncsHandlerName(m.Name))
+		var e ast.Expr = ast.NewIdent("e")
+		if et.nested[m.Name] {
+			// values of nested enum are members of this enum
+			e = &ast.TypeAssertExpr{
+				X:    e,
+				Type: enum,
+			}
+		}
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  handler,
+				Op: token.EQL,
+				Y:  nilExpr,
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IfStmt{
+						Cond: &ast.BinaryExpr{
+							X:  selector(f, "Default"),
+							Op: token.EQL,
+							Y:  nilExpr,
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								returnStmt(
+									nilExpr,
+									call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("%s: no handler for %s", funcsTypeName, m.Name))),
+								),
+							},
+						},
+					},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							handler,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.FuncLit{
+								Type: &ast.FuncType{
+									Params:  sig.params(field("e", et.member(m))),
+									Results: sig.resultList(),
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										sig.callStmt(call(selector(f, "Default"), sig.callArgs(e)...)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	stmts = append(stmts, returnStmt(
+		call(et.instance("__"+funcsTypeName), f),
+		nilExpr,
+	))
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("f", et.instance(funcsTypeName)),
+		),
+		Name: ast.NewIdent("Build"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", et.instance(r.visitorTypeName(enumIdent))),
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
+func visitorFuncsImpl(r *namingRegistry, enumIdent, funcsTypeName string, member *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (v __ExampleVisitorFuncs) VisitA(e A) R {
+	// 	return v.OnA(e)
+	// }
+
+	var (
+		visitor = ast.NewIdent("v")
+		enumVal = ast.NewIdent("e")
+	)
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("v", et.instance("__"+funcsTypeName)),
+		),
+		Name: ast.NewIdent(r.visitMethodName(enumIdent, member.Name)),
+		Type: &ast.FuncType{
+			Params:  sig.params(field("e", et.member(member))),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				sig.callStmt(call(selector(visitor, visitorFuncsHandlerName(member.Name)), sig.callArgs(enumVal)...)),
+			},
+		},
+	}
+}
@@ -2,111 +2,275 @@ package gen
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/token"
+	"go/types"
 	"log"
 	"os"
-	"strconv"
+	"path/filepath"
+	"runtime"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/imports"
-)
 
-const (
-	packagePath          = "github.com/daichitakahashi/go-enum"
-	enumSymbol           = "MemberOf"
-	visitorReturnsSymbol = "VisitorReturns"
+	"github.com/daichitakahashi/go-enum/internal/enumtype"
 )
 
-func loadPackage() (*packages.Package, error) {
+// DefaultFilename is the name of generated file used when Config.Filename is empty.
+const DefaultFilename = "enum.gen.go"
+
+// Config is the configuration of Generate.
+type Config struct {
+	// Dir is the directory where the patterns are resolved. If empty, the current directory is used.
+	Dir string
+	// Patterns are the package patterns to generate(e.g. "./..."). If empty, the package in Dir is used.
+	Patterns []string
+	// Filename is the name of generated file placed in each package directory.
+	Filename string
+
+	// Visitors is the list of naming parameters of visitor type and visit methods.
+	Visitors []NamingVisitorParams
+	// Accepts is the list of naming parameters of accept method.
+	Accepts []NamingAcceptParams
+	// VisitorImpls is the list of naming parameters of visitor implementation and its factory.
+	VisitorImpls []NamingVisitorImplParams
+	// VisitorFuncs is the list of naming parameters of visitor built from handler funcs.
+	VisitorFuncs []NamingVisitorFuncsParams
+	// Sealed is the list of target patterns of enum identifiers to be sealed.
+	Sealed []string
+	// Matches is the list of naming parameters of generic match function.
+	Matches []NamingMatchParams
+	// Multis is the list of naming parameters of visitor fanning out to multiple visitors.
+	Multis []NamingMultiParams
+	// JSON is the list of target patterns of enum identifiers to generate JSON encoding functions.
+	JSON []string
+	// Kinds is the list of naming parameters of Kind type and methods.
+	Kinds []NamingKindParams
+	// ByName is the list of target patterns of enum identifiers to generate constructor by member name.
+	ByName []string
+	// Lists is the list of target patterns of enum identifiers to generate listing and iteration of members.
+	Lists []string
+	// Union is the list of target patterns of enum identifiers to generate union constraint of members with Is and As functions.
+	Union []string
+	// Flatten is the list of target patterns of enum identifiers whose visitor visits members of nested enums directly.
+	Flatten []string
+	// Unimplemented is the list of target patterns of enum identifiers to generate unimplemented and no-op visitors.
+	Unimplemented []string
+	// Wrap is the list of target patterns of enum identifiers to generate visitor middleware.
+	Wrap []string
+	// Bus is the list of target patterns of enum identifiers to generate in-process bus.
+	Bus []string
+	// Pointer is the list of target patterns of enum identifiers whose members are referred by pointer.
+	Pointer []string
+
+	// Overlay is passed to packages.Config to load the package with the file contents not saved to disk.
+	Overlay map[string][]byte
+}
+
+func loadPackages(ctx context.Context, cfg Config) ([]*packages.Package, error) {
+	patterns := cfg.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
 	pkgs, err := packages.Load(&packages.Config{
-		Mode:  packages.NeedSyntax | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedName,
-		Dir:   ".",
-		Tests: false,
-	}, "")
+		Mode: packages.NeedSyntax | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedName |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+		Context: ctx,
+		Dir:     cfg.Dir,
+		Tests:   false,
+		Overlay: cfg.Overlay,
+	}, patterns...)
 	if err != nil {
 		return nil, err
 	}
+	if len(pkgs) == 0 {
+		return nil, errors.New("no package loaded")
+	}
+	return pkgs, nil
+}
 
-	for _, pkg := range pkgs {
-		// return first package
-		return pkg, nil
+func packageErrors(pkg *packages.Package) error {
+	var errs []error
+	for _, err := range pkg.Errors {
+		// Generated file may be out of date or not generated yet, and then the package cannot be type-checked
+		// completely. Type errors are tolerated because type information of the declarations is still available.
+		if err.Kind != packages.TypeError {
+			errs = append(errs, packageError(err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Generate generates the code of enums declared in the packages matched with cfg.Patterns.
+// Packages are processed concurrently, and packages without enums are skipped.
+// It returns the generated code keyed by the path of the file to be written, and never writes files by itself.
+// If a package without enums has the file generated previously, the file is stale and keyed with nil code to be removed.
+//
+// Errors of each package are reported as *PackageError, and errors caused by the source code are reported as *Error.
+// Even if it returns an error, the returned map contains the code of the packages generated successfully.
+// If no enum is found in any package, ErrNoEnum is returned.
+func Generate(ctx context.Context, cfg Config) (map[string][]byte, error) {
+	if cfg.Filename == "" {
+		cfg.Filename = DefaultFilename
 	}
 
-	return nil, errors.New("no package loaded")
+	pkgs, err := loadPackages(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		filename string
+		code     []byte
+		err      error
+	}
+	var (
+		results = make([]result, len(pkgs))
+		sem     = make(chan struct{}, runtime.GOMAXPROCS(0))
+		wg      sync.WaitGroup
+	)
+	for i, pkg := range pkgs {
+		i, pkg := i, pkg
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				results[i].err = err
+				return
+			}
+			if err := packageErrors(pkg); err != nil {
+				results[i].err = err
+				return
+			}
+			if len(pkg.GoFiles) == 0 {
+				results[i].err = ErrNoEnum
+				return
+			}
+			results[i].filename = filepath.Join(filepath.Dir(pkg.GoFiles[0]), cfg.Filename)
+			results[i].code, results[i].err = generate(pkg, cfg)
+			if errors.Is(results[i].err, ErrNoEnum) && hasGeneratedFile(pkg, cfg.Filename) {
+				results[i].err = nil
+			}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		files = map[string][]byte{}
+		errs  []error
+	)
+	for i, r := range results {
+		switch {
+		case errors.Is(r.err, ErrNoEnum):
+		case r.err != nil:
+			errs = append(errs, &PackageError{
+				PkgPath: pkgs[i].PkgPath,
+				Err:     r.err,
+			})
+		default:
+			files[r.filename] = r.code
+		}
+	}
+	if len(errs) > 0 {
+		return files, errors.Join(errs...)
+	}
+	if len(files) == 0 {
+		return nil, ErrNoEnum
+	}
+	return files, nil
 }
 
+// Run generates the code of the package in wd, and writes it to filename in the package directory.
+// It exits the process on any error.
+//
+// Deprecated: Use Generate, which reports errors instead of exiting and never writes files by itself.
 func Run(wd, filename string, namingVisitor []NamingVisitorParams, namingAccept []NamingAcceptParams, namingVisitorFactory []NamingVisitorImplParams) {
-	err := os.Chdir(wd)
+	files, err := Generate(context.Background(), Config{
+		Dir:          wd,
+		Filename:     filename,
+		Visitors:     namingVisitor,
+		Accepts:      namingAccept,
+		VisitorImpls: namingVisitorFactory,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
+	for filename, code := range files {
+		if code == nil {
+			if err := os.Remove(filename); err != nil {
+				log.Fatal(err)
+			}
+			continue
+		}
+		if err := os.WriteFile(filename, code, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
 
-	pkg, err := loadPackage()
-	if err != nil {
-		log.Fatal(err)
+// Report whether the package has the file generated previously by enumgen.
+func hasGeneratedFile(pkg *packages.Package, filename string) bool {
+	for _, f := range pkg.Syntax {
+		if filepath.Base(pkg.Fset.File(f.Pos()).Name()) != filename {
+			continue
+		}
+		return len(f.Comments) > 0 && f.Comments[0].List[0].Text == codeGeneratedMark
 	}
+	return false
+}
 
+// Report whether pos is in the file generated previously, which is replaced by the generated code.
+func isGeneratedPos(pkg *packages.Package, pos token.Pos, filename string) bool {
+	return filepath.Base(pkg.Fset.Position(pos).Filename) == filename
+}
+
+func generate(pkg *packages.Package, cfg Config) ([]byte, error) {
 	f := &ast.File{
 		Name: ast.NewIdent(pkg.Name),
 	}
-	registry := newNamingRegistry(namingVisitor, namingAccept, namingVisitorFactory)
+	registry := newNamingRegistry(cfg)
+	imported := newImportSet(pkg.Types)
+	errs := &errorList{}
 
-	type targetFile struct {
-		file        *ast.File
-		enumPackage string
-	}
-	collectTargetPackages := pipelineStage(func(in *ast.File, out chan targetFile) {
-		var collected bool
-		for _, spec := range in.Imports {
-			importPath, err := strconv.Unquote(spec.Path.Value)
-			if err != nil {
-				log.Fatal(err)
-			}
-			if importPath == packagePath {
-				name := "enum"
-				if spec.Name != nil {
-					name = spec.Name.String()
-				}
-				// TODO: consider dot import
-				out <- targetFile{
-					file:        in,
-					enumPackage: name,
-				}
-				collected = true
-			}
-		}
-		if collected {
-			// collect import specs
-			f.Decls = append(
-				f.Decls,
-				importSpecs(in.Imports),
-			)
+	collectTargetFiles := pipelineStage(func(in *ast.File, out chan *ast.File) {
+		// skip the file generated previously
+		if filepath.Base(pkg.Fset.File(in.Pos()).Name()) == cfg.Filename {
+			return
 		}
+		out <- in
 	})
 
-	collectEnumDefinitions := pipelineStage(func(in targetFile, out chan enumMemberDefinition) {
-		for _, decl := range in.file.Decls {
+	collectEnumDefinitions := pipelineStage(func(in *ast.File, out chan enumMemberDefinition) {
+		for _, decl := range in.Decls {
 			if typeDecl, ok := decl.(*ast.GenDecl); ok {
 				for _, spec := range typeDecl.Specs {
 					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-						if def, ok := extractEnumMemberDefinition(in.enumPackage, typeSpec); ok {
-							out <- *def
+						defs, defErrs := extractEnumMemberDefinitions(pkg.Fset, pkg.Types, pkg.TypesInfo, typeSpec)
+						for _, err := range defErrs {
+							errs.add(err)
+						}
+						for _, def := range defs {
+							out <- def
 						}
 					}
 				}
 			}
 		}
 	})
-	collectEnumIdentDefinitions := pipelineStage(func(in targetFile, out chan enumIdentDefinition) {
-		for _, decl := range in.file.Decls {
+	collectEnumIdentDefinitions := pipelineStage(func(in *ast.File, out chan enumIdentDefinition) {
+		for _, decl := range in.Decls {
 			if typeDecl, ok := decl.(*ast.GenDecl); ok {
 				for _, spec := range typeDecl.Specs {
 					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-						if def, ok := extractEnumIdentDefinition(in.enumPackage, typeSpec); ok {
+						if def, ok := extractEnumIdentDefinition(pkg.TypesInfo, typeSpec); ok {
 							out <- *def
 						}
 					}
@@ -117,7 +281,7 @@ func Run(wd, filename string, namingVisitor []NamingVisitorParams, namingAccept
 	collectDefinitions := merge(collectEnumDefinitions, collectEnumIdentDefinitions, func(o1 []enumMemberDefinition, o2 []enumIdentDefinition) pair[[]enumMemberDefinition, map[string]enumIdentDefinition] {
 		m := make(map[string]enumIdentDefinition)
 		for _, d := range o2 {
-			m[fmt.Sprint(d.ident)] = d
+			m[d.ident.Name()] = d
 		}
 		return pair[[]enumMemberDefinition, map[string]enumIdentDefinition]{
 			left:  o1,
@@ -127,35 +291,146 @@ func Run(wd, filename string, namingVisitor []NamingVisitorParams, namingAccept
 
 	// assemble enumInfo per enum type
 	type enumInfo struct {
-		ident              ast.Expr
-		members            []*ast.Ident
-		visitorReturnIdent ast.Expr
+		ident   string
+		members []*ast.Ident // direct members to be visited
+		leaves  []enumLeaf   // concrete member types
+		sig     *signature
+		tp      *typeParams // type parameters of generic enum, nil if not generic
 	}
 	assembleEnumInfo := func(in pair[[]enumMemberDefinition, map[string]enumIdentDefinition]) <-chan enumInfo {
 		var (
-			dict = map[string]*enumInfo{}
-			list []*enumInfo
+			defs  = map[string][]enumMemberDefinition{}
+			infos = map[string]*enumInfo{}
+			list  []*enumInfo
 		)
 
 		for _, def := range in.left {
-			enumIdent := fmt.Sprint(def.enumIdent)
-
-			var visitorReturnIdent ast.Expr
-			if e, ok := in.right[enumIdent]; ok {
-				visitorReturnIdent = e.visitorReturnIdent
-			}
+			enumIdent := def.enumIdent.Name()
 
-			if info, ok := dict[enumIdent]; ok {
-				info.members = append(info.members, def.ident)
-			} else {
+			if _, ok := defs[enumIdent]; !ok {
+				sig := &signature{}
+				if e, ok := in.right[enumIdent]; ok {
+					if e.visitorContext {
+						sig.context = &ast.SelectorExpr{
+							X:   ast.NewIdent(imported.add("context", "context")),
+							Sel: ast.NewIdent("Context"),
+						}
+					}
+					for _, t := range e.visitorArgs {
+						expr, err := imported.typeExpr(t)
+						if err != nil {
+							errs.add(newError(pkg.Fset.Position(e.ident.Pos()), "visitor argument type of %s: %s", enumIdent, err))
+						}
+						sig.args = append(sig.args, expr)
+					}
+					for _, t := range e.visitorReturns {
+						expr, err := imported.typeExpr(t)
+						if err != nil {
+							errs.add(newError(pkg.Fset.Position(e.ident.Pos()), "visitor return type of %s: %s", enumIdent, err))
+						}
+						sig.results = append(sig.results, expr)
+					}
+					if n := len(e.visitorReturns); n > 0 {
+						sig.returnsError = types.Identical(e.visitorReturns[n-1], types.Universe.Lookup("error").Type())
+					}
+				}
+				var tp *typeParams
+				if params := def.enumIdent.Type().(*types.Named).TypeParams(); params.Len() > 0 {
+					tp = newTypeParams()
+					for i := 0; i < params.Len(); i++ {
+						param := params.At(i)
+						expr, err := imported.typeExpr(param.Constraint())
+						if err != nil {
+							errs.add(newError(pkg.Fset.Position(param.Obj().Pos()), "type constraint of %s: %s", enumIdent, err))
+						}
+						tp.add(param.Obj().Name(), expr)
+					}
+				}
 				info := &enumInfo{
-					ident:              def.enumIdent,
-					members:            []*ast.Ident{def.ident},
-					visitorReturnIdent: visitorReturnIdent,
+					ident: enumIdent,
+					sig:   sig,
+					tp:    tp,
 				}
-				dict[enumIdent] = info
+				infos[enumIdent] = info
 				list = append(list, info)
 			}
+			defs[enumIdent] = append(defs[enumIdent], def)
+
+			tp := infos[enumIdent].tp
+			if def.nested && (tp != nil || def.typeArgs != nil) {
+				errs.add(newError(def.pos, "generic enum %s cannot be nested", enumIdent))
+			}
+			if tp != nil {
+				tp.addMember(def.ident.Name, def.typeArgs)
+			}
+			if def.nested && registry.isPointer(enumIdent) != registry.isPointer(def.ident.Name) {
+				errs.add(newError(def.pos, "nested enum %s and %s must be both referred by pointer or not", def.ident.Name, enumIdent))
+			}
+		}
+
+		methods := map[string]map[string]string{} // member -> method name -> enum identifier
+		for _, info := range list {
+			if _, _, found := registry.multiFuncName(info.ident); found {
+				if n := len(info.sig.results); n > 1 || (n == 1 && !info.sig.returnsError) {
+					errs.add(newError(pkg.Fset.Position(defs[info.ident][0].enumIdent.Pos()), "--multi requires visitor of %s to return nothing or error", info.ident))
+				}
+			}
+			if info.tp != nil && registry.isByName(info.ident) {
+				errs.add(newError(pkg.Fset.Position(defs[info.ident][0].enumIdent.Pos()), "--by-name is not supported for generic enum %s", info.ident))
+			}
+			if registry.isByName(info.ident) && !registry.isPointer(info.ident) {
+				// the zero value wrapped in the interface is not addressable, so decoders cannot decode into it
+				errs.add(newError(pkg.Fset.Position(defs[info.ident][0].enumIdent.Pos()), "--by-name requires --pointer for %s", info.ident))
+			}
+			if registry.isUnion(info.ident) {
+				// exported names generated with the union must not clash with the declarations of the package
+				for _, name := range unionDeclNames(info.ident) {
+					if obj := pkg.Types.Scope().Lookup(name); obj != nil && !isGeneratedPos(pkg, obj.Pos(), cfg.Filename) {
+						errs.add(newError(pkg.Fset.Position(obj.Pos()), "%s is already declared, which is generated by --union for %s", name, info.ident))
+					}
+				}
+			}
+			leaves, err := resolveLeaves(info.ident, defs, registry.isFlatten(info.ident))
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			info.leaves = leaves
+			info.members = visitedMembers(leaves)
+
+			// member names must be unique in the enum
+			used := map[string]string{} // member name or alias -> member
+			for _, l := range leaves {
+				for _, name := range append([]string{l.def.name.name}, l.def.name.aliases...) {
+					if member, ok := used[name]; ok {
+						errs.add(newError(l.def.pos, "duplicate name %q of %s member: already used by %s", name, info.ident, member))
+						continue
+					}
+					used[name] = l.def.ident.Name
+				}
+			}
+
+			// methods declared on the member joining multiple enums must not collide
+			for _, l := range leaves {
+				member := l.def.ident.Name
+				if methods[member] == nil {
+					methods[member] = map[string]string{}
+				}
+				for _, method := range registry.memberMethodNames(info.ident) {
+					if other, ok := methods[member][method]; ok {
+						msg := fmt.Sprintf("method %s of %s is generated for both %s and %s", method, member, other, info.ident)
+						switch {
+						case method == registry.acceptMethodName(info.ident):
+							msg += ": give distinct names with --accept"
+						case registry.hasKind(info.ident) && method == registry.kindMethodName(info.ident):
+							msg += ": give distinct names with --kind"
+						}
+						errs.add(newError(l.def.pos, "%s", msg))
+						continue
+					}
+					methods[member][method] = info.ident
+				}
+			}
 		}
 
 		out := make(chan enumInfo)
@@ -169,36 +444,169 @@ func Run(wd, filename string, namingVisitor []NamingVisitorParams, namingAccept
 	}
 
 	generateDecl := pipelineStage(func(in enumInfo, out chan ast.Decl) {
-		enumIdent := fmt.Sprint(in.ident)
+		var (
+			enumIdent = in.ident
+			leaves    = leafIdents(in.leaves)
+			names     = leafNames(in.leaves)
+			et        = &enumTypes{
+				typeParams: in.tp,
+				pointer:    registry.isPointer(in.ident),
+				nested:     map[string]bool{},
+			}
+		)
+		for _, l := range in.leaves {
+			if l.via != l.def.ident {
+				et.nested[l.via.Name] = true
+			}
+		}
+		specs := []ast.Spec{
+			visitorSpec(registry, enumIdent, in.members, in.sig, et),
+			enumSpec(registry, enumIdent, in.sig, et),
+		}
+		if registry.isUnion(enumIdent) {
+			specs = append(specs, memberConstraintSpec(enumIdent, leaves, et))
+		}
 		out <- &ast.GenDecl{
-			Tok: token.TYPE,
-			Specs: []ast.Spec{
-				visitorSpec(registry, enumIdent, in.members, in.visitorReturnIdent),
-				enumSpec(registry, enumIdent, in.visitorReturnIdent),
-			},
+			Tok:   token.TYPE,
+			Specs: specs,
 		}
 
 		// implementations of Accept
-		for _, m := range in.members {
-			out <- acceptImpl(registry, enumIdent, m, in.visitorReturnIdent)
+		for _, l := range in.leaves {
+			out <- acceptImpl(registry, enumIdent, l.def.ident, l.via, in.sig, et)
+		}
+
+		// marker methods of sealed enum
+		if registry.isSealed(enumIdent) {
+			for _, m := range leaves {
+				out <- sealedImpl(registry, enumIdent, m, et)
+			}
 		}
 
 		// type checks
-		out <- typeCheckDecl(enumIdent, in.members)
+		out <- typeCheckDecl(enumIdent, leaves, et)
+
+		// narrowing by union of members
+		if registry.isUnion(enumIdent) {
+			out <- isFunc(enumIdent, et)
+			out <- asFunc(enumIdent, et)
+		}
+
+		// listing members
+		if registry.isList(enumIdent) {
+			out <- countConstDecl(enumIdent, leaves)
+			out <- allMembersFunc(enumIdent, leaves, et)
+			out <- membersSeqFunc(enumIdent, et)
+		}
+
+		// kind of members
+		if registry.hasKind(enumIdent) {
+			fmtPkg := imported.add("fmt", "fmt")
+			out <- kindSpec(registry, enumIdent)
+			out <- kindConstDecl(registry, enumIdent, leaves)
+			for _, m := range leaves {
+				out <- kindMethodImpl(registry, enumIdent, m, et)
+			}
+			out <- kindStringFunc(registry, enumIdent, fmtPkg, leaves, names)
+			out <- parseKindFunc(registry, enumIdent, fmtPkg, leaves, names)
+			out <- allKindsFunc(registry, enumIdent, leaves)
+		}
+
+		// generic match function
+		if matchFunc, found := registry.matchFuncName(enumIdent); found {
+			out <- matchFuncDecl(registry, enumIdent, matchFunc, imported.add("fmt", "fmt"), in.members, in.leaves, et)
+		}
+
+		// JSON encoding
+		if registry.isJSON(enumIdent) {
+			jsonPkg := imported.add("encoding/json", "json")
+			fmtPkg := imported.add("fmt", "fmt")
+			out <- jsonEnvelopeSpec(enumIdent, jsonPkg)
+			out <- marshalJSONFunc(enumIdent, jsonPkg, fmtPkg, leaves, names, et)
+			out <- unmarshalJSONFunc(enumIdent, jsonPkg, fmtPkg, leaves, names, et)
+		}
+
+		// construction by name
+		if registry.isByName(enumIdent) {
+			for _, m := range leaves {
+				out <- factoryFunc(enumIdent, m, et)
+			}
+			out <- factoriesDecl(enumIdent, leaves, names)
+			out <- newByNameFunc(enumIdent, imported.add("fmt", "fmt"))
+			out <- namesFunc(enumIdent, names)
+		}
 
 		// visitor impl factory
 		visitorFactory, found := registry.visitorImplFactoryName(enumIdent)
 		if found {
-			out <- visitorImplSpec(registry, enumIdent, in.members, in.visitorReturnIdent)
-			out <- visitorFactoryImpl(registry, enumIdent, visitorFactory, in.members, in.visitorReturnIdent)
+			out <- visitorImplSpec(registry, enumIdent, in.members, in.sig, et)
+			out <- visitorFactoryImpl(registry, enumIdent, visitorFactory, in.members, in.sig, et)
+			for _, m := range in.members {
+				out <- visitorImpl(registry, enumIdent, m, in.sig, et)
+			}
+		}
+
+		// visitors to be embedded
+		if registry.isUnimplemented(enumIdent) {
+			enumPkg := imported.add(enumtype.PackagePath, "enum")
+			fmtPkg := imported.add("fmt", "fmt")
+			out <- unimplementedSpec(registry, enumIdent, et)
+			for _, m := range in.members {
+				out <- unimplementedImpl(registry, enumIdent, enumPkg, fmtPkg, m, in.sig, et)
+			}
+			for _, m := range in.members {
+				out <- nopImpl(registry, enumIdent, m, in.sig, et)
+			}
+		}
+
+		// visitor middleware
+		if registry.isWrap(enumIdent) {
+			out <- wrapFunc(registry, enumIdent, in.sig, et)
+			out <- wrapSpec(registry, enumIdent, in.sig, et)
+			kinds := visitedMemberNames(in.leaves)
+			for i, m := range in.members {
+				out <- wrapImpl(registry, enumIdent, m, kinds[i], in.sig, et)
+			}
+		}
+
+		// visitor fanning out to multiple visitors
+		if multiName, join, found := registry.multiFuncName(enumIdent); found {
+			var errorsPkg string
+			if join && len(in.sig.results) > 0 {
+				errorsPkg = imported.add("errors", "errors")
+			}
+			out <- multiFunc(registry, enumIdent, multiName, et)
+			out <- multiSpec(registry, enumIdent, multiName, et)
+			for _, m := range in.members {
+				out <- multiImpl(registry, enumIdent, multiName, errorsPkg, join, m, in.sig, et)
+			}
+		}
+
+		// in-process bus
+		if registry.isBus(enumIdent) {
+			enumPkg := imported.add(enumtype.PackagePath, "enum")
+			out <- busSpec(registry, enumIdent, enumPkg, et)
+			out <- newBusFunc(registry, enumIdent, enumPkg, et)
+			contextPkg := imported.add("context", "context")
+			for i, m := range leaves {
+				out <- subscribeFunc(registry, enumIdent, contextPkg, m, names[i], in.sig, et)
+			}
+			out <- publishFunc(registry, enumIdent, contextPkg, imported.add("fmt", "fmt"), leaves, names, et)
+			out <- closeBusFunc(registry, enumIdent, et)
+		}
+
+		// visitor built from handler funcs
+		if funcsType, found := registry.visitorFuncsTypeName(enumIdent); found {
+			out <- visitorFuncsSpec(registry, enumIdent, funcsType, in.members, in.sig, et)
+			out <- visitorFuncsBuild(registry, enumIdent, funcsType, imported.add("fmt", "fmt"), in.members, in.sig, et)
 			for _, m := range in.members {
-				out <- visitorImpl(registry, enumIdent, m, in.visitorReturnIdent)
+				out <- visitorFuncsImpl(registry, enumIdent, funcsType, m, in.sig, et)
 			}
 		}
 	})
 
 	decls :=
-		pipe(collectTargetPackages,
+		pipe(collectTargetFiles,
 			pipe(collectDefinitions,
 				pipe(assembleEnumInfo,
 					generateDecl,
@@ -209,18 +617,15 @@ func Run(wd, filename string, namingVisitor []NamingVisitorParams, namingAccept
 	for decl := range decls {
 		f.Decls = append(f.Decls, decl)
 	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
 	if len(f.Decls) == 0 {
-		log.Fatal("target type not found")
+		return nil, ErrNoEnum
 	}
+	f.Decls = append([]ast.Decl{imported.decl()}, f.Decls...)
 
-	code, err := generateCode(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = os.WriteFile(filename, code, 0644)
-	if err != nil {
-		log.Fatal(err)
-	}
+	return generateCode(f)
 }
 
 const codeGeneratedMark = `// Code generated by enumgen. DO NOT EDIT.`
@@ -239,7 +644,7 @@ func generateCode(f *ast.File) ([]byte, error) {
 		Comments:   true,
 		TabIndent:  true,
 		TabWidth:   8,
-		FormatOnly: false,
+		FormatOnly: true, // required imports are already declared
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,221 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	code, filename, err := generateTestdata(t, "basic", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(code, golden) {
+		t.Errorf("generated code differs from %s:\n%s", filename, code)
+	}
+}
+
+func TestGenerateFilename(t *testing.T) {
+	code, _, err := generateTestdata(t, "basic", Config{Filename: "fruits.gen.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != nil {
+		t.Error("generated code must be keyed by the configured filename")
+	}
+}
+
+// Generate reads the contents of Overlay instead of the files on disk, and never writes files.
+func TestGenerateOverlay(t *testing.T) {
+	src, err := filepath.Abs(filepath.Join("testdata", "basic", "fruits.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, filename, err := generateTestdata(t, "basic", Config{
+		Overlay: map[string][]byte{
+			src: []byte(`package basic
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Grape  struct {
+		enum.MemberOf[Fruits]
+	}
+)
+`),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(code, []byte("VisitGrape(e Grape)")) || bytes.Contains(code, []byte("VisitApple")) {
+		t.Errorf("generated code does not reflect overlay:\n%s", code)
+	}
+	golden, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(golden, []byte("Grape")) {
+		t.Errorf("%s is written", filename)
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	for name, c := range map[string]struct {
+		dir     string
+		overlay string // content of fruits.go, if any
+		line    int
+		msg     string
+	}{
+		"source": {
+			dir:  "invalid",
+			line: 11,
+			msg:  `duplicate name "fruit" of Fruits member: already used by Apple`,
+		},
+		"syntax": {
+			dir:     "basic",
+			overlay: "package basic\n\ntype Fruits interface{\n",
+			line:    3,
+		},
+	} {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			src, err := filepath.Abs(filepath.Join("testdata", c.dir, "fruits.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cfg Config
+			if c.overlay != "" {
+				cfg.Overlay = map[string][]byte{
+					src: []byte(c.overlay),
+				}
+			}
+			_, _, err = generateTestdata(t, c.dir, cfg)
+
+			var pkgErr *PackageError
+			if !errors.As(err, &pkgErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasSuffix(pkgErr.PkgPath, "/testdata/"+c.dir) {
+				t.Errorf("unexpected package path: %s", pkgErr.PkgPath)
+			}
+			var srcErr *Error
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if srcErr.Pos.Filename != src || srcErr.Pos.Line != c.line {
+				t.Errorf("unexpected position: %s", srcErr.Pos)
+			}
+			if c.msg != "" && srcErr.Msg != c.msg {
+				t.Errorf("unexpected message: %s", srcErr.Msg)
+			}
+		})
+	}
+}
+
+func TestGenerateNoEnum(t *testing.T) {
+	code, _, err := generateTestdata(t, "noenum", Config{})
+	if !errors.Is(err, ErrNoEnum) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if code != nil {
+		t.Error("unexpected code generated")
+	}
+}
+
+// Kind methods of the member joining multiple enums must have distinct names.
+func TestGenerateKindMethodName(t *testing.T) {
+	accepts := []NamingAcceptParams{{Target: "Color", MethodName: "AcceptColor"}}
+
+	_, _, err := generateTestdata(t, "multikind", Config{
+		Accepts: accepts,
+		Kinds:   []NamingKindParams{{Target: "*", MethodName: "Kind"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "method Kind of Apple is generated for both Fruits and Color: give distinct names with --kind") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	code, _, err := generateTestdata(t, "multikind", Config{
+		Accepts: accepts,
+		Kinds:   []NamingKindParams{{Target: "*", MethodName: "*Kind"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, decl := range []string{"func (Apple) FruitsKind() FruitsKind", "func (Apple) ColorKind() ColorKind"} {
+		if !bytes.Contains(code, []byte(decl)) {
+			t.Errorf("%s is not generated:\n%s", decl, code)
+		}
+	}
+}
+
+// The member constructed by name must be addressable for decoders.
+func TestGenerateByNameRequiresPointer(t *testing.T) {
+	_, _, err := generateTestdata(t, "basic", Config{ByName: []string{"*"}})
+	if err == nil || !strings.Contains(err.Error(), "--by-name requires --pointer for Fruits") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	code, _, err := generateTestdata(t, "basic", Config{ByName: []string{"*"}, Pointer: []string{"*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(code, []byte("return &Apple{}")) {
+		t.Errorf("factory does not return pointer:\n%s", code)
+	}
+}
+
+func TestGenerateUnion(t *testing.T) {
+	code, _, err := generateTestdata(t, "basic", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(code, []byte("FruitsMember")) {
+		t.Errorf("union is generated without --union:\n%s", code)
+	}
+
+	// declarations in the file generated previously are replaced
+	dir, err := filepath.Abs(filepath.Join("testdata", "basic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _, err = generateTestdata(t, "basic", Config{
+		Union: []string{"*"},
+		Overlay: map[string][]byte{
+			filepath.Join(dir, DefaultFilename): []byte(codeGeneratedMark + "\n\npackage basic\n\ntype FruitsMember interface{ Apple | Orange }\n"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, decl := range []string{"FruitsMember interface", "func IsFruits[M FruitsMember](v Fruits) bool", "func AsFruits[M FruitsMember](v Fruits) (M, bool)"} {
+		if !bytes.Contains(code, []byte(decl)) {
+			t.Errorf("%s is not generated:\n%s", decl, code)
+		}
+	}
+}
+
+// The names generated by --union must not clash with the declarations of the package.
+func TestGenerateUnionClash(t *testing.T) {
+	if _, _, err := generateTestdata(t, "unionclash", Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := generateTestdata(t, "unionclash", Config{Union: []string{"*"}})
+	var srcErr *Error
+	if !errors.As(err, &srcErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if srcErr.Msg != "IsFruits is already declared, which is generated by --union for Fruits" || srcErr.Pos.Line != 16 {
+		t.Errorf("unexpected error: %s", srcErr)
+	}
+}
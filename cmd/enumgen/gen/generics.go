@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// typeParams holds type parameters of the generic enum identifier, nil if the enum is not generic.
+//
+//	type Result[T any] interface{}
+//	type Ok[T any] struct{ enum.MemberOf[Result[T]] }
+//
+// Generated declarations share type parameters of the enum identifier(e.g. `ResultVisitor[T any]`),
+// and refer the members with them(e.g. `func (e Ok[T]) Accept(v ResultVisitor[T])`).
+type typeParams struct {
+	list    []*ast.Field          // type parameters with constraints
+	names   []string              // names of type parameters
+	members map[string][]ast.Expr // type arguments of each generic member
+}
+
+func newTypeParams() *typeParams {
+	return &typeParams{
+		members: map[string][]ast.Expr{},
+	}
+}
+
+func (p *typeParams) add(name string, constraint ast.Expr) {
+	p.list = append(p.list, field(name, constraint))
+	p.names = append(p.names, name)
+}
+
+// Register the generic member, whose i-th type parameter is indexes[i]-th type parameter of the enum.
+func (p *typeParams) addMember(member string, indexes []int) {
+	args := make([]ast.Expr, 0, len(indexes))
+	for _, i := range indexes {
+		args = append(args, ast.NewIdent(p.names[i]))
+	}
+	p.members[member] = args
+}
+
+// params returns type parameter list of generated type or function, which may be nil.
+func (p *typeParams) params(extra ...*ast.Field) *ast.FieldList {
+	var list []*ast.Field
+	if p != nil {
+		list = append(list, p.list...)
+	}
+	list = append(list, extra...)
+	if len(list) == 0 {
+		return nil
+	}
+	return fieldList(list...)
+}
+
+// args returns type parameters as type arguments.
+func (p *typeParams) args() []ast.Expr {
+	if p == nil {
+		return nil
+	}
+	args := make([]ast.Expr, 0, len(p.names))
+	for _, name := range p.names {
+		args = append(args, ast.NewIdent(name))
+	}
+	return args
+}
+
+// instance returns the generated type(or function) instantiated with type parameters, e.g. `ResultVisitor[T]`.
+func (p *typeParams) instance(name string) ast.Expr {
+	return instantiate(ast.NewIdent(name), p.args())
+}
+
+// member returns the member type instantiated with type parameters, e.g. `Ok[T]`.
+func (p *typeParams) member(m *ast.Ident) ast.Expr {
+	if p == nil {
+		return m
+	}
+	return instantiate(m, p.members[m.Name])
+}
+
+// unusedName returns the name of additional type parameter not conflicting with type parameters of the enum.
+func (p *typeParams) unusedName(name string) string {
+	if p == nil {
+		return name
+	}
+	used := map[string]bool{}
+	for _, n := range p.names {
+		used[n] = true
+	}
+	candidate := name
+	for i := 1; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	return candidate
+}
+
+func instantiate(x ast.Expr, args []ast.Expr) ast.Expr {
+	switch len(args) {
+	case 0:
+		return x
+	case 1:
+		return &ast.IndexExpr{
+			X:     x,
+			Index: args[0],
+		}
+	default:
+		return &ast.IndexListExpr{
+			X:       x,
+			Indices: args,
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// importSet collects packages referred from generated code.
+type importSet struct {
+	mu    sync.Mutex
+	pkg   *types.Package
+	names map[string]string // package path to local name
+	paths map[string]string // local name to package path
+}
+
+func newImportSet(pkg *types.Package) *importSet {
+	return &importSet{
+		pkg:   pkg,
+		names: map[string]string{},
+		paths: map[string]string{},
+	}
+}
+
+// qualifier implements types.Qualifier, and records the package as import.
+func (s *importSet) qualifier(p *types.Package) string {
+	if p == s.pkg {
+		return ""
+	}
+	return s.add(p.Path(), p.Name())
+}
+
+// add records the package path and returns its local name.
+// If the name conflicts with other package, numbered name is used.
+func (s *importSet) add(path, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if local, ok := s.names[path]; ok {
+		return local
+	}
+	local := name
+	for n := 2; ; n++ {
+		if _, ok := s.paths[local]; !ok {
+			break
+		}
+		local = fmt.Sprintf("%s%d", name, n)
+	}
+	s.names[path] = local
+	s.paths[local] = path
+	return local
+}
+
+// typeExpr converts resolved type into expression valid in the generated file.
+func (s *importSet) typeExpr(t types.Type) (ast.Expr, error) {
+	if t == types.Typ[types.Invalid] {
+		return nil, fmt.Errorf("invalid type")
+	}
+	return parser.ParseExpr(types.TypeString(t, s.qualifier))
+}
+
+func (s *importSet) decl() *ast.GenDecl {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := make([]string, 0, len(s.names))
+	for p := range s.names {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	specs := make([]*ast.ImportSpec, 0, len(paths))
+	for _, p := range paths {
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: strconv.Quote(p),
+			},
+		}
+		if local := s.names[p]; local != path.Base(p) {
+			spec.Name = ast.NewIdent(local)
+		}
+		specs = append(specs, spec)
+	}
+	return importSpecs(specs)
+}
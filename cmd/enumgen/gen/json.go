@@ -0,0 +1,254 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// JSON encoding of enum identifier as the envelope `{"type": "A", "data": {...}}`.
+// The discriminator is the member name, which can be customized by the tag of MemberOf field.
+
+func jsonEnvelopeTypeName(enumIdent string) string {
+	return fmt.Sprintf("__%sJSON", enumIdent)
+}
+
+func jsonEnvelopeSpec(enumIdent, jsonPkg string) *ast.GenDecl {
+	// type __ExampleJSON struct {
+	// 	Type string          `json:"type"`
+	// 	Data json.RawMessage `json:"data"`
+	// }
+
+	typeField := field("Type", ast.NewIdent("string"))
+	typeField.Tag = &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: "`json:\"type\"`",
+	}
+	dataField := field("Data", selector(ast.NewIdent(jsonPkg), "RawMessage"))
+	dataField.Tag = &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: "`json:\"data\"`",
+	}
+
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(jsonEnvelopeTypeName(enumIdent)),
+				Type: &ast.StructType{
+					Fields: fieldList(typeField, dataField),
+				},
+			},
+		},
+	}
+}
+
+func marshalJSONFunc(enumIdent, jsonPkg, fmtPkg string, members []*ast.Ident, names []memberName, et *enumTypes) *ast.FuncDecl {
+	// func MarshalExampleJSON(v Example) ([]byte, error) {
+	// 	var typ string
+	// 	switch v.(type) {
+	// 	case A:
+	// 		typ = "A"
+	// 	case B:
+	// 		typ = "B"
+	// 	default:
+	// 		return nil, fmt.Errorf("unexpected Example: %T", v)
+	// 	}
+	// 	data, err := json.Marshal(v)
+	// 	if err != nil {
+	// 		return nil, err
+	// 	}
+	// 	return json.Marshal(__ExampleJSON{Type: typ, Data: data})
+	// }
+
+	var (
+		v       = ast.NewIdent("v")
+		typ     = ast.NewIdent("typ")
+		data    = ast.NewIdent("data")
+		err     = ast.NewIdent("err")
+		nilExpr = ast.NewIdent("nil")
+
+		clauses []ast.Stmt
+	)
+	for i, m := range members {
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{
+				et.member(m),
+			},
+			Body: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						typ,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						stringLit(names[i].name),
+					},
+				},
+			},
+		})
+	}
+	clauses = append(clauses, &ast.CaseClause{
+		Body: []ast.Stmt{
+			returnStmt(
+				nilExpr,
+				call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("unexpected %s: %%T", enumIdent)), v),
+			),
+		},
+	})
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("Marshal%sJSON", enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params: fieldList(
+				field("v", et.instance(enumIdent)),
+			),
+			Results: fieldList(
+				field("", &ast.ArrayType{
+					Elt: ast.NewIdent("byte"),
+				}),
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				varDecl("typ", ast.NewIdent("string")),
+				&ast.TypeSwitchStmt{
+					Assign: &ast.ExprStmt{
+						X: &ast.TypeAssertExpr{
+							X: v,
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				define([]ast.Expr{data, err}, call(selector(ast.NewIdent(jsonPkg), "Marshal"), v)),
+				ifErrReturn(nil, nilExpr, err),
+				returnStmt(
+					call(selector(ast.NewIdent(jsonPkg), "Marshal"), &ast.CompositeLit{
+						Type: ast.NewIdent(jsonEnvelopeTypeName(enumIdent)),
+						Elts: []ast.Expr{
+							&ast.KeyValueExpr{
+								Key:   ast.NewIdent("Type"),
+								Value: typ,
+							},
+							&ast.KeyValueExpr{
+								Key:   ast.NewIdent("Data"),
+								Value: data,
+							},
+						},
+					}),
+				),
+			},
+		},
+	}
+}
+
+func unmarshalJSONFunc(enumIdent, jsonPkg, fmtPkg string, members []*ast.Ident, names []memberName, et *enumTypes) *ast.FuncDecl {
+	// func UnmarshalExampleJSON(data []byte) (Example, error) {
+	// 	var envelope __ExampleJSON
+	// 	if err := json.Unmarshal(data, &envelope); err != nil {
+	// 		return nil, err
+	// 	}
+	// 	switch envelope.Type {
+	// 	case "A", "a":
+	// 		var v A
+	// 		if len(envelope.Data) > 0 { // data may be omitted for members without fields
+	// 			if err := json.Unmarshal(envelope.Data, &v); err != nil {
+	// 				return nil, err
+	// 			}
+	// 		}
+	// 		return v, nil // or &v, if members are referred by pointer
+	// 	...
+	// 	}
+	// 	return nil, fmt.Errorf("unknown type of Example: %q", envelope.Type)
+	// }
+
+	var (
+		v        = ast.NewIdent("v")
+		data     = ast.NewIdent("data")
+		envelope = ast.NewIdent("envelope")
+		nilExpr  = ast.NewIdent("nil")
+		err      = ast.NewIdent("err")
+
+		clauses []ast.Stmt
+	)
+	for i, m := range members {
+		// aliases are accepted as well
+		list := []ast.Expr{
+			stringLit(names[i].name),
+		}
+		for _, alias := range names[i].aliases {
+			list = append(list, stringLit(alias))
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: list,
+			Body: []ast.Stmt{
+				varDecl("v", et.value(m)),
+				&ast.IfStmt{
+					// data may be omitted for members without fields
+					Cond: &ast.BinaryExpr{
+						X:  call(ast.NewIdent("len"), selector(envelope, "Data")),
+						Op: token.GTR,
+						Y: &ast.BasicLit{
+							Kind:  token.INT,
+							Value: "0",
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							ifErrReturn(
+								call(selector(ast.NewIdent(jsonPkg), "Unmarshal"), selector(envelope, "Data"), &ast.UnaryExpr{
+									Op: token.AND,
+									X:  v,
+								}),
+								nilExpr, err,
+							),
+						},
+					},
+				},
+				returnStmt(et.ref(v), nilExpr),
+			},
+		})
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("Unmarshal%sJSON", enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params: fieldList(
+				field("data", &ast.ArrayType{
+					Elt: ast.NewIdent("byte"),
+				}),
+			),
+			Results: fieldList(
+				field("", et.instance(enumIdent)),
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				varDecl("envelope", ast.NewIdent(jsonEnvelopeTypeName(enumIdent))),
+				ifErrReturn(
+					call(selector(ast.NewIdent(jsonPkg), "Unmarshal"), data, &ast.UnaryExpr{
+						Op: token.AND,
+						X:  envelope,
+					}),
+					nilExpr, err,
+				),
+				&ast.SwitchStmt{
+					Tag: selector(envelope, "Type"),
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				returnStmt(
+					nilExpr,
+					call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("unknown type of %s: %%q", enumIdent)), selector(envelope, "Type")),
+				),
+			},
+		},
+	}
+}
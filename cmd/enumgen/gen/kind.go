@@ -0,0 +1,233 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Comparable discriminator of the members, `type ExampleKind int`.
+
+func kindSpec(r *namingRegistry, enumIdent string) *ast.GenDecl {
+	// type ExampleKind int
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(r.kindTypeName(enumIdent)),
+				Type: ast.NewIdent("int"),
+			},
+		},
+	}
+}
+
+func kindConstDecl(r *namingRegistry, enumIdent string, members []*ast.Ident) *ast.GenDecl {
+	// const (
+	// 	ExampleKindA ExampleKind = iota + 1
+	// 	ExampleKindB
+	// )
+	// zero value is reserved as invalid kind
+
+	specs := make([]ast.Spec, 0, len(members))
+	for i, m := range members {
+		spec := &ast.ValueSpec{
+			Names: []*ast.Ident{
+				ast.NewIdent(r.kindConstName(enumIdent, m.Name)),
+			},
+		}
+		if i == 0 {
+			spec.Type = ast.NewIdent(r.kindTypeName(enumIdent))
+			spec.Values = []ast.Expr{
+				&ast.BinaryExpr{
+					X:  ast.NewIdent("iota"),
+					Op: token.ADD,
+					Y: &ast.BasicLit{
+						Kind:  token.INT,
+						Value: "1",
+					},
+				},
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return &ast.GenDecl{
+		Tok:    token.CONST,
+		Lparen: 1, // force parenthesized form
+		Specs:  specs,
+	}
+}
+
+func kindMethodImpl(r *namingRegistry, enumIdent string, member *ast.Ident, et *enumTypes) *ast.FuncDecl {
+	// func (A) Kind() ExampleKind {
+	// 	return ExampleKindA
+	// }
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("", et.member(member)),
+		),
+		Name: ast.NewIdent(r.kindMethodName(enumIdent)),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", ast.NewIdent(r.kindTypeName(enumIdent))),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(ast.NewIdent(r.kindConstName(enumIdent, member.Name))),
+			},
+		},
+	}
+}
+
+func kindStringFunc(r *namingRegistry, enumIdent, fmtPkg string, members []*ast.Ident, names []memberName) *ast.FuncDecl {
+	// func (k ExampleKind) String() string {
+	// 	switch k {
+	// 	case ExampleKindA:
+	// 		return "A"
+	// 	...
+	// 	}
+	// 	return fmt.Sprintf("ExampleKind(%d)", int(k))
+	// }
+
+	var (
+		k        = ast.NewIdent("k")
+		kindType = r.kindTypeName(enumIdent)
+
+		clauses []ast.Stmt
+	)
+	for i, m := range members {
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{
+				ast.NewIdent(r.kindConstName(enumIdent, m.Name)),
+			},
+			Body: []ast.Stmt{
+				returnStmt(stringLit(names[i].name)),
+			},
+		})
+	}
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("k", ast.NewIdent(kindType)),
+		),
+		Name: ast.NewIdent("String"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", ast.NewIdent("string")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.SwitchStmt{
+					Tag: k,
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				returnStmt(
+					call(selector(ast.NewIdent(fmtPkg), "Sprintf"), stringLit(kindType+"(%d)"), call(ast.NewIdent("int"), k)),
+				),
+			},
+		},
+	}
+}
+
+func parseKindFunc(r *namingRegistry, enumIdent, fmtPkg string, members []*ast.Ident, names []memberName) *ast.FuncDecl {
+	// func ParseExampleKind(s string) (ExampleKind, error) {
+	// 	switch s {
+	// 	case "A", "a":
+	// 		return ExampleKindA, nil
+	// 	...
+	// 	}
+	// 	return 0, fmt.Errorf("unknown ExampleKind: %q", s)
+	// }
+
+	var (
+		s        = ast.NewIdent("s")
+		nilExpr  = ast.NewIdent("nil")
+		kindType = r.kindTypeName(enumIdent)
+
+		clauses []ast.Stmt
+	)
+	for i, m := range members {
+		// aliases are accepted as well
+		list := []ast.Expr{
+			stringLit(names[i].name),
+		}
+		for _, alias := range names[i].aliases {
+			list = append(list, stringLit(alias))
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: list,
+			Body: []ast.Stmt{
+				returnStmt(ast.NewIdent(r.kindConstName(enumIdent, m.Name)), nilExpr),
+			},
+		})
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("Parse" + kindType),
+		Type: &ast.FuncType{
+			Params: fieldList(
+				field("s", ast.NewIdent("string")),
+			),
+			Results: fieldList(
+				field("", ast.NewIdent(kindType)),
+				field("", ast.NewIdent("error")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.SwitchStmt{
+					Tag: s,
+					Body: &ast.BlockStmt{
+						List: clauses,
+					},
+				},
+				returnStmt(
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: "0",
+					},
+					call(selector(ast.NewIdent(fmtPkg), "Errorf"), stringLit(fmt.Sprintf("unknown %s: %%q", kindType)), s),
+				),
+			},
+		},
+	}
+}
+
+func allKindsFunc(r *namingRegistry, enumIdent string, members []*ast.Ident) *ast.FuncDecl {
+	// func AllExampleKinds() []ExampleKind {
+	// 	return []ExampleKind{ExampleKindA, ExampleKindB}
+	// }
+
+	kindType := r.kindTypeName(enumIdent)
+	elts := make([]ast.Expr, 0, len(members))
+	for _, m := range members {
+		elts = append(elts, ast.NewIdent(r.kindConstName(enumIdent, m.Name)))
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("All%ss", kindType)),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: fieldList(
+				field("", &ast.ArrayType{
+					Elt: ast.NewIdent(kindType),
+				}),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.CompositeLit{
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent(kindType),
+					},
+					Elts: elts,
+				}),
+			},
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Listing and iteration of the members at runtime.
+
+func countConstDecl(enumIdent string, members []*ast.Ident) *ast.GenDecl {
+	// const ExampleCount = 2
+	return &ast.GenDecl{
+		Tok: token.CONST,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent(fmt.Sprintf("%sCount", enumIdent)),
+				},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: strconv.Itoa(len(members)),
+					},
+				},
+			},
+		},
+	}
+}
+
+func allMembersFunc(enumIdent string, members []*ast.Ident, et *enumTypes) *ast.FuncDecl {
+	// func AllExample() []Example {
+	// 	return []Example{A{}, B{}}
+	// }
+	sliceType := &ast.ArrayType{
+		Elt: et.instance(enumIdent),
+	}
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("All%s", enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params:     &ast.FieldList{},
+			Results: fieldList(
+				field("", sliceType),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.CompositeLit{
+					Type: sliceType,
+					Elts: zeroValues(members, et),
+				}),
+			},
+		},
+	}
+}
+
+func membersSeqFunc(enumIdent string, et *enumTypes) *ast.FuncDecl {
+	// func ExampleMembers() func(yield func(Example) bool) {
+	// 	return func(yield func(Example) bool) {
+	// 		for _, m := range AllExample() {
+	// 			if !yield(m) {
+	// 				return
+	// 			}
+	// 		}
+	// 	}
+	// }
+	// The result is compatible with iter.Seq[Example] without importing iter(Go 1.23).
+
+	var (
+		yield = ast.NewIdent("yield")
+		m     = ast.NewIdent("m")
+	)
+	seqType := func() *ast.FuncType {
+		return &ast.FuncType{
+			Params: fieldList(
+				field("yield", &ast.FuncType{
+					Params: fieldList(
+						field("", et.instance(enumIdent)),
+					),
+					Results: fieldList(
+						field("", ast.NewIdent("bool")),
+					),
+				}),
+			),
+		}
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("%sMembers", enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params:     &ast.FieldList{},
+			Results: fieldList(
+				field("", seqType()),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.FuncLit{
+					Type: seqType(),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.RangeStmt{
+								Key:   ast.NewIdent("_"),
+								Value: m,
+								Tok:   token.DEFINE,
+								X:     call(et.instance(fmt.Sprintf("All%s", enumIdent))),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.IfStmt{
+											Cond: &ast.UnaryExpr{
+												Op: token.NOT,
+												X:  call(yield, m),
+											},
+											Body: &ast.BlockStmt{
+												List: []ast.Stmt{
+													returnStmt(),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+}
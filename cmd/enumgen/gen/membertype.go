@@ -0,0 +1,51 @@
+package gen
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// enumTypes determines how generated code refers the types of the enum and its members.
+type enumTypes struct {
+	*typeParams                 // type parameters of generic enum, nil if not generic
+	pointer     bool            // whether members are referred by pointer(e.g. `func (e *A) Accept(v ExampleVisitor)`)
+	nested      map[string]bool // nested enum identifiers, which are never referred by pointer
+}
+
+func (t *enumTypes) generic() bool {
+	return t.typeParams != nil
+}
+
+// member returns the member type, e.g. `A`, `*A` or `*A[T]`.
+func (t *enumTypes) member(m *ast.Ident) ast.Expr {
+	typ := t.typeParams.member(m)
+	if t.pointer && !t.nested[m.Name] {
+		return &ast.StarExpr{
+			X: typ,
+		}
+	}
+	return typ
+}
+
+// value returns the member type without pointer, e.g. `A` or `A[T]`.
+func (t *enumTypes) value(m *ast.Ident) ast.Expr {
+	return t.typeParams.member(m)
+}
+
+// zero returns the zero value of the member, e.g. `A{}` or `&A{}`.
+func (t *enumTypes) zero(m *ast.Ident) ast.Expr {
+	return t.ref(&ast.CompositeLit{
+		Type: t.value(m),
+	})
+}
+
+// ref returns the expression referring the member value x, e.g. `x` or `&x`.
+func (t *enumTypes) ref(x ast.Expr) ast.Expr {
+	if t.pointer {
+		return &ast.UnaryExpr{
+			Op: token.AND,
+			X:  x,
+		}
+	}
+	return x
+}
@@ -0,0 +1,152 @@
+package gen
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Visitor fanning out each visit to multiple visitors, available if the visitor returns nothing or error.
+
+func multiFunc(r *namingRegistry, enumIdent, multiFuncName string, et *enumTypes) *ast.FuncDecl {
+	// func MultiExampleVisitor(vs ...ExampleVisitor) ExampleVisitor {
+	// 	return __MultiExampleVisitor(vs)
+	// }
+
+	visitorType := et.instance(r.visitorTypeName(enumIdent))
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(multiFuncName),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params: fieldList(
+				field("vs", &ast.Ellipsis{
+					Elt: visitorType,
+				}),
+			),
+			Results: fieldList(
+				field("", visitorType),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(call(et.instance("__"+multiFuncName), ast.NewIdent("vs"))),
+			},
+		},
+	}
+}
+
+func multiSpec(r *namingRegistry, enumIdent, multiFuncName string, et *enumTypes) *ast.GenDecl {
+	// type __MultiExampleVisitor []ExampleVisitor
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       ast.NewIdent("__" + multiFuncName),
+				TypeParams: et.params(),
+				Type: &ast.ArrayType{
+					Elt: et.instance(r.visitorTypeName(enumIdent)),
+				},
+			},
+		},
+	}
+}
+
+func multiImpl(r *namingRegistry, enumIdent, multiFuncName, errorsPkg string, join bool, member *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (m __MultiExampleVisitor) VisitA(e A) error {
+	// 	for _, v := range m {
+	// 		if err := v.VisitA(e); err != nil {
+	// 			return err
+	// 		}
+	// 	}
+	// 	return nil
+	// }
+	// or, joining errors of all visitors
+	// func (m __MultiExampleVisitor) VisitA(e A) error {
+	// 	var errs []error
+	// 	for _, v := range m {
+	// 		if err := v.VisitA(e); err != nil {
+	// 			errs = append(errs, err)
+	// 		}
+	// 	}
+	// 	return errors.Join(errs...)
+	// }
+
+	var (
+		v           = ast.NewIdent("v")
+		err         = ast.NewIdent("err")
+		errs        = ast.NewIdent("errs")
+		visitMethod = r.visitMethodName(enumIdent, member.Name)
+		visit       = call(selector(v, visitMethod), sig.callArgs(ast.NewIdent("e"))...)
+
+		body  ast.Stmt
+		stmts []ast.Stmt
+	)
+	switch {
+	case len(sig.results) == 0:
+		body = &ast.ExprStmt{
+			X: visit,
+		}
+	case join:
+		stmts = append(stmts, varDecl("errs", &ast.ArrayType{
+			Elt: ast.NewIdent("error"),
+		}))
+		body = &ast.IfStmt{
+			Init: define([]ast.Expr{err}, visit),
+			Cond: &ast.BinaryExpr{
+				X:  err,
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							errs,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							call(ast.NewIdent("append"), errs, err),
+						},
+					},
+				},
+			},
+		}
+	default:
+		body = ifErrReturn(visit, err)
+	}
+	stmts = append(stmts, &ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: v,
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("m"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				body,
+			},
+		},
+	})
+	switch {
+	case len(sig.results) == 0:
+	case join:
+		stmts = append(stmts, returnStmt(&ast.CallExpr{
+			Fun:      selector(ast.NewIdent(errorsPkg), "Join"),
+			Args:     []ast.Expr{errs},
+			Ellipsis: 1,
+		}))
+	default:
+		stmts = append(stmts, returnStmt(ast.NewIdent("nil")))
+	}
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("m", et.instance("__"+multiFuncName)),
+		),
+		Name: ast.NewIdent(visitMethod),
+		Type: &ast.FuncType{
+			Params:  sig.params(field("e", et.member(member))),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
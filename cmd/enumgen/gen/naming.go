@@ -23,10 +23,45 @@ type NamingVisitorImplParams struct {
 	FactoryName string
 }
 
+type NamingVisitorFuncsParams struct {
+	Target   string
+	TypeName string
+}
+
+type NamingMultiParams struct {
+	Target   string
+	FuncName string
+	Join     bool // aggregate errors of all visitors with errors.Join, instead of stopping at the first error
+}
+
+type NamingMatchParams struct {
+	Target   string
+	FuncName string
+}
+
+type NamingKindParams struct {
+	Target     string
+	MethodName string
+}
+
 type namingRegistry struct {
 	visitors     []NamingVisitorParams
 	accepts      []NamingAcceptParams
 	visitorImpls []NamingVisitorImplParams
+	visitorFuncs []NamingVisitorFuncsParams
+	sealed       []string // target patterns of sealed enum
+	matches      []NamingMatchParams
+	multis       []NamingMultiParams
+	jsons        []string // target patterns of enum encoded as JSON
+	kinds        []NamingKindParams
+	unimpls      []string // target patterns of enum having unimplemented visitor
+	wraps        []string // target patterns of enum having visitor middleware
+	buses        []string // target patterns of enum delivered by bus
+	byNames      []string // target patterns of enum constructed by name
+	lists        []string // target patterns of enum listing members
+	unions       []string // target patterns of enum having union constraint of members
+	flattens     []string // target patterns of enum visiting members of nested enums directly
+	pointers     []string // target patterns of enum whose members are referred by pointer
 
 	visitorParamsCache map[string]*NamingVisitorParams
 	visitorTypeCache   map[string]string
@@ -34,11 +69,25 @@ type namingRegistry struct {
 	acceptMethodCache  map[string]string
 }
 
-func newNamingRegistry(visitors []NamingVisitorParams, accepts []NamingAcceptParams, visitorImpls []NamingVisitorImplParams) *namingRegistry {
+func newNamingRegistry(cfg Config) *namingRegistry {
 	return &namingRegistry{
-		visitors:     visitors,
-		accepts:      accepts,
-		visitorImpls: visitorImpls,
+		visitors:     cfg.Visitors,
+		accepts:      cfg.Accepts,
+		visitorImpls: cfg.VisitorImpls,
+		visitorFuncs: cfg.VisitorFuncs,
+		sealed:       cfg.Sealed,
+		matches:      cfg.Matches,
+		multis:       cfg.Multis,
+		jsons:        cfg.JSON,
+		kinds:        cfg.Kinds,
+		unimpls:      cfg.Unimplemented,
+		wraps:        cfg.Wrap,
+		buses:        cfg.Bus,
+		byNames:      cfg.ByName,
+		lists:        cfg.Lists,
+		unions:       cfg.Union,
+		flattens:     cfg.Flatten,
+		pointers:     cfg.Pointer,
 
 		visitorParamsCache: map[string]*NamingVisitorParams{},
 		visitorTypeCache:   map[string]string{},
@@ -127,3 +176,142 @@ func (r *namingRegistry) visitorImplFactoryName(enumIdent string) (string, bool)
 	name := strings.Replace(namingParams.FactoryName, "*", visitorTypeName, 1)
 	return name, true
 }
+
+func (r *namingRegistry) visitorFuncsTypeName(enumIdent string) (string, bool) {
+	for _, f := range r.visitorFuncs {
+		if wildcard.MatchSimple(f.Target, enumIdent) {
+			return strings.Replace(f.TypeName, "*", r.visitorTypeName(enumIdent), 1), true
+		}
+	}
+	return "", false
+}
+
+func (r *namingRegistry) isSealed(enumIdent string) bool {
+	return matchAny(r.sealed, enumIdent)
+}
+
+func (r *namingRegistry) isJSON(enumIdent string) bool {
+	return matchAny(r.jsons, enumIdent)
+}
+
+func (r *namingRegistry) isUnimplemented(enumIdent string) bool {
+	return matchAny(r.unimpls, enumIdent)
+}
+
+func (r *namingRegistry) unimplementedTypeName(enumIdent string) string {
+	return "Unimplemented" + r.visitorTypeName(enumIdent)
+}
+
+func (r *namingRegistry) nopTypeName(enumIdent string) string {
+	return "Nop" + r.visitorTypeName(enumIdent)
+}
+
+func (r *namingRegistry) isWrap(enumIdent string) bool {
+	return matchAny(r.wraps, enumIdent)
+}
+
+func (r *namingRegistry) wrapFuncName(enumIdent string) string {
+	return "Wrap" + r.visitorTypeName(enumIdent)
+}
+
+func (r *namingRegistry) isBus(enumIdent string) bool {
+	return matchAny(r.buses, enumIdent)
+}
+
+func (r *namingRegistry) busTypeName(enumIdent string) string {
+	return fmt.Sprintf("%sBus", enumIdent)
+}
+
+func (r *namingRegistry) hasKind(enumIdent string) bool {
+	_, found := r.kindParams(enumIdent)
+	return found
+}
+
+func (r *namingRegistry) kindParams(enumIdent string) (NamingKindParams, bool) {
+	for _, k := range r.kinds {
+		if wildcard.MatchSimple(k.Target, enumIdent) {
+			return k, true
+		}
+	}
+	return NamingKindParams{}, false
+}
+
+func (r *namingRegistry) isByName(enumIdent string) bool {
+	return matchAny(r.byNames, enumIdent)
+}
+
+func (r *namingRegistry) isList(enumIdent string) bool {
+	return matchAny(r.lists, enumIdent)
+}
+
+func (r *namingRegistry) isUnion(enumIdent string) bool {
+	return matchAny(r.unions, enumIdent)
+}
+
+func (r *namingRegistry) isFlatten(enumIdent string) bool {
+	return matchAny(r.flattens, enumIdent)
+}
+
+func (r *namingRegistry) isPointer(enumIdent string) bool {
+	return matchAny(r.pointers, enumIdent)
+}
+
+func matchAny(targets []string, enumIdent string) bool {
+	for _, target := range targets {
+		if wildcard.MatchSimple(target, enumIdent) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unexported method to seal enum identifier, which is implemented only by the members.
+func (r *namingRegistry) sealedMethodName(enumIdent string) string {
+	return fmt.Sprintf("__%sEnum", enumIdent)
+}
+
+func (r *namingRegistry) kindTypeName(enumIdent string) string {
+	return fmt.Sprintf("%sKind", enumIdent)
+}
+
+func (r *namingRegistry) kindConstName(enumIdent, memberName string) string {
+	return fmt.Sprintf("%sKind%s", enumIdent, memberName)
+}
+
+func (r *namingRegistry) kindMethodName(enumIdent string) string {
+	params, _ := r.kindParams(enumIdent)
+	return strings.Replace(params.MethodName, "*", enumIdent, 1)
+}
+
+// Names of the methods declared on each member of the enum, shared by all enums.
+func (r *namingRegistry) memberMethodNames(enumIdent string) []string {
+	names := []string{r.acceptMethodName(enumIdent)}
+	if r.hasKind(enumIdent) {
+		names = append(names, r.kindMethodName(enumIdent))
+	}
+	return names
+}
+
+func (r *namingRegistry) matchFuncName(enumIdent string) (string, bool) {
+	for _, m := range r.matches {
+		if wildcard.MatchSimple(m.Target, enumIdent) {
+			return strings.Replace(m.FuncName, "*", enumIdent, 1), true
+		}
+	}
+	return "", false
+}
+
+func (r *namingRegistry) multiFuncName(enumIdent string) (name string, join, found bool) {
+	for _, m := range r.multis {
+		if wildcard.MatchSimple(m.Target, enumIdent) {
+			return strings.Replace(m.FuncName, "*", r.visitorTypeName(enumIdent), 1), m.Join, true
+		}
+	}
+	return "", false, false
+}
+
+// Name of the callback parameter of match function, derived from visit method name.
+func (r *namingRegistry) matchCallbackName(enumIdent, memberName string) string {
+	name := r.visitMethodName(enumIdent, memberName)
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"go/ast"
+)
+
+// enumLeaf is the concrete member type of the enum, which may join the enum through nested enums.
+//
+//	Event -> OrderEvent -> OrderPlaced
+//
+// OrderPlaced is the leaf of Event, visited as OrderEvent by EventVisitor(or as OrderPlaced if flattened).
+type enumLeaf struct {
+	def enumMemberDefinition // definition in the innermost enum
+	via *ast.Ident           // direct member of the enum to be visited
+}
+
+// Resolve leaves of the enum recursively, in declaration order.
+func resolveLeaves(enumIdent string, defs map[string][]enumMemberDefinition, flatten bool) ([]enumLeaf, *Error) {
+	return collectLeaves(enumIdent, defs, flatten, map[string]bool{})
+}
+
+func collectLeaves(enumIdent string, defs map[string][]enumMemberDefinition, flatten bool, visiting map[string]bool) ([]enumLeaf, *Error) {
+	visiting[enumIdent] = true
+	defer delete(visiting, enumIdent)
+
+	var leaves []enumLeaf
+	for _, def := range defs[enumIdent] {
+		if !def.nested {
+			leaves = append(leaves, enumLeaf{
+				def: def,
+				via: def.ident,
+			})
+			continue
+		}
+
+		sub := def.ident.Name
+		if visiting[sub] {
+			return nil, newError(def.pos, "cyclic nesting of enum %s and %s", enumIdent, sub)
+		}
+		if len(defs[sub]) == 0 {
+			return nil, newError(def.pos, "nested enum %s has no members", sub)
+		}
+		subLeaves, err := collectLeaves(sub, defs, false, visiting)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range subLeaves {
+			via := def.ident
+			if flatten {
+				via = l.def.ident
+			}
+			leaves = append(leaves, enumLeaf{
+				def: l.def,
+				via: via,
+			})
+		}
+	}
+	return leaves, nil
+}
+
+// Direct members of the enum to be visited, in declaration order.
+func visitedMembers(leaves []enumLeaf) []*ast.Ident {
+	var (
+		members []*ast.Ident
+		seen    = map[string]bool{}
+	)
+	for _, l := range leaves {
+		if !seen[l.via.Name] {
+			seen[l.via.Name] = true
+			members = append(members, l.via)
+		}
+	}
+	return members
+}
+
+func leafIdents(leaves []enumLeaf) []*ast.Ident {
+	idents := make([]*ast.Ident, 0, len(leaves))
+	for _, l := range leaves {
+		idents = append(idents, l.def.ident)
+	}
+	return idents
+}
+
+func leafNames(leaves []enumLeaf) []memberName {
+	names := make([]memberName, 0, len(leaves))
+	for _, l := range leaves {
+		names = append(names, l.def.name)
+	}
+	return names
+}
+
+// Names of direct members to be visited, in the same order as visitedMembers.
+// Nested enum identifier has no member tag, so it is named by itself.
+func visitedMemberNames(leaves []enumLeaf) []string {
+	var (
+		names []string
+		seen  = map[string]bool{}
+	)
+	for _, l := range leaves {
+		if !seen[l.via.Name] {
+			seen[l.via.Name] = true
+			name := l.via.Name
+			if l.via == l.def.ident {
+				name = l.def.name.name
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -1,92 +1,225 @@
 package gen
 
-import "go/ast"
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
 
-// Extract type parameter T from `pkgName.symbol[T]`.
-func extractSymbolExpr(pkgName, symbol string, expr *ast.IndexExpr) (ast.Expr, bool) {
-	if sel, ok := expr.X.(*ast.SelectorExpr); ok {
-		if pkg, ok := sel.X.(*ast.Ident); ok {
-			if pkg.String() != pkgName {
-				return nil, false
+	"github.com/daichitakahashi/go-enum/internal/enumtype"
+)
+
+// Extract return types of visitor method from enum identifier interface (`VisitorReturns[T]`, `VisitorReturns2[T1, T2]`...)
+func findVisitorReturnsFromEmbeddeds(i *types.Interface) ([]types.Type, bool) {
+	for n := 0; n < i.NumEmbeddeds(); n++ {
+		for _, symbol := range enumtype.VisitorReturnsSymbols {
+			if visitorReturns, ok := enumtype.TypeArgs(symbol, i.EmbeddedType(n)); ok {
+				return visitorReturns, true
 			}
 		}
-		if sel.Sel.String() != symbol {
-			return nil, false
-		}
 	}
-	return expr.Index, true
+	return nil, false
 }
 
-// Extract interface used as enum identifier T from struct (`pkgName.MemberOf[T]`).
-func findEnumIdentFromFields(pkgName string, s *ast.StructType) (ast.Expr, bool) {
-	for _, f := range s.Fields.List {
-		if len(f.Names) == 0 {
-			if expr, ok := f.Type.(*ast.IndexExpr); ok {
-				enumIdent, ok := extractSymbolExpr(pkgName, enumSymbol, expr)
-				if ok {
-					return enumIdent, true
-				}
-			}
+// Find marker of context parameter of visitor method from enum identifier interface (`VisitorContext`)
+func findVisitorContextFromEmbeddeds(i *types.Interface) bool {
+	for n := 0; n < i.NumEmbeddeds(); n++ {
+		if enumtype.Is(enumtype.VisitorContextSymbol, i.EmbeddedType(n)) {
+			return true
 		}
 	}
-	return nil, false
+	return false
 }
 
-// Extract return type of visitor method from enum identifier interface (`pkgName.VisitorReturns[T]`)
-func findVisitorReturnsFromFields(pkgName string, i *ast.InterfaceType) (ast.Expr, bool) {
-	for _, f := range i.Methods.List {
-		if len(f.Names) == 0 {
-			if expr, ok := f.Type.(*ast.IndexExpr); ok {
-				visitorReturnIdent, ok := extractSymbolExpr(pkgName, visitorReturnsSymbol, expr)
-				if ok {
-					return visitorReturnIdent, true
-				}
+// Extract additional parameter types of visitor method from enum identifier interface (`VisitorArgs[A]`, `VisitorArgs2[A1, A2]`...)
+func findVisitorArgsFromEmbeddeds(i *types.Interface) ([]types.Type, bool) {
+	for n := 0; n < i.NumEmbeddeds(); n++ {
+		for _, symbol := range enumtype.VisitorArgsSymbols {
+			if visitorArgs, ok := enumtype.TypeArgs(symbol, i.EmbeddedType(n)); ok {
+				return visitorArgs, true
 			}
 		}
 	}
 	return nil, false
 }
 
+// memberName is the name of the member used in serialization, and its aliases accepted in parsing.
+type memberName struct {
+	name    string
+	aliases []string
+}
+
 type enumMemberDefinition struct {
 	ident     *ast.Ident
-	enumIdent ast.Expr
+	enumIdent *types.TypeName
+	name      memberName
+	pos       token.Position
+	nested    bool  // whether the member is the nested enum identifier
+	typeArgs  []int // for generic member, index of type parameter of the enum identifier per its type parameter
 }
 
-func extractEnumMemberDefinition(enumPackage string, spec *ast.TypeSpec) (*enumMemberDefinition, bool) {
-	switch s := spec.Type.(type) {
-	case *ast.StructType:
-		// type A struct { enumPackage.MemberOf[Ident] }
-		if ident, ok := findEnumIdentFromFields(enumPackage, s); ok {
-			return &enumMemberDefinition{
-				ident:     spec.Name,
-				enumIdent: ident,
-			}, true
+// Extract definitions of the member type, which may join multiple enums.
+func extractEnumMemberDefinitions(fset *token.FileSet, pkg *types.Package, info *types.Info, spec *ast.TypeSpec) ([]enumMemberDefinition, []*Error) {
+	var (
+		defs []enumMemberDefinition
+		errs []*Error
+	)
+	for _, member := range enumtype.MemberOf(info, spec) {
+		obj, err := enumIdentTypeName(pkg, member.EnumIdent)
+		if err != nil {
+			errs = append(errs, newError(fset.Position(member.Expr.Pos()), "%s", err))
+			continue
 		}
-	case *ast.IndexExpr:
-		// type A enumPackage.MemberOf[Ident]
-		if ident, ok := extractSymbolExpr(enumPackage, enumSymbol, s); ok {
-			return &enumMemberDefinition{
-				ident:     spec.Name,
-				enumIdent: ident,
-			}, true
+		typeArgs, err := memberTypeArgs(info, spec, member.EnumIdent)
+		if err != nil {
+			errs = append(errs, newError(fset.Position(member.Expr.Pos()), "%s", err))
+			continue
+		}
+		name := memberName{
+			name: spec.Name.Name,
 		}
+		if member.Tag != nil {
+			if err := parseMemberTag(member.Tag.Value, &name); err != nil {
+				errs = append(errs, newError(fset.Position(member.Tag.Pos()), "invalid tag of %s: %s", spec.Name.Name, err))
+				continue
+			}
+		}
+		defs = append(defs, enumMemberDefinition{
+			ident:     ast.NewIdent(spec.Name.Name),
+			enumIdent: obj,
+			name:      name,
+			pos:       fset.Position(member.Expr.Pos()),
+			nested:    member.Nested,
+			typeArgs:  typeArgs,
+		})
 	}
-	return nil, false
+	return defs, errs
+}
+
+// Map type parameters of the generic member to those of the generic enum identifier.
+// Type arguments of the enum identifier must be exactly type parameters of the member(e.g. `Ok[T] struct{ enum.MemberOf[Result[T]] }`).
+func memberTypeArgs(info *types.Info, spec *ast.TypeSpec, enumIdent types.Type) ([]int, error) {
+	obj, ok := info.Defs[spec.Name].(*types.TypeName)
+	if !ok {
+		return nil, nil
+	}
+	member, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		named   = enumtype.Unalias(enumIdent).(*types.Named) // already validated
+		params  = member.TypeParams()
+		args    = named.TypeArgs()
+		generic = named.Origin().TypeParams().Len() > 0
+	)
+	if !generic {
+		if params.Len() > 0 {
+			return nil, fmt.Errorf("generic member %s cannot join non-generic enum %s", obj.Name(), named.Obj().Name())
+		}
+		return nil, nil
+	}
+
+	invalid := fmt.Errorf("type arguments of enum %s must be type parameters of %s", named.Obj().Name(), obj.Name())
+	if params.Len() != args.Len() {
+		return nil, invalid
+	}
+	indexes := make([]int, params.Len())
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	for i := 0; i < args.Len(); i++ {
+		tp, ok := args.At(i).(*types.TypeParam)
+		if !ok || tp.Index() >= params.Len() || params.At(tp.Index()) != tp || indexes[tp.Index()] >= 0 {
+			return nil, invalid
+		}
+		indexes[tp.Index()] = i
+	}
+	return indexes, nil
+}
+
+// Parse the tag of MemberOf field such as `enum:"name=order.placed,alias=orderPlaced"`.
+// Key "alias" can be specified multiple times.
+func parseMemberTag(lit string, name *memberName) error {
+	tag, err := strconv.Unquote(lit)
+	if err != nil {
+		return err
+	}
+	value, ok := reflect.StructTag(tag).Lookup("enum")
+	if !ok {
+		return nil
+	}
+	var named bool
+	for _, part := range strings.Split(value, ",") {
+		key, v, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found || v == "" {
+			return fmt.Errorf("%q must be the form key=value", part)
+		}
+		switch key {
+		case "name":
+			if named {
+				return fmt.Errorf("duplicate name")
+			}
+			name.name = v
+			named = true
+		case "alias":
+			name.aliases = append(name.aliases, v)
+		default:
+			return fmt.Errorf("unknown key %q", key)
+		}
+	}
+	return nil
+}
+
+// Resolve enum identifier type which must be an interface declared in the package same as its members.
+func enumIdentTypeName(pkg *types.Package, t types.Type) (*types.TypeName, error) {
+	if t == types.Typ[types.Invalid] {
+		return nil, fmt.Errorf("invalid enum identifier")
+	}
+	named, ok := enumtype.Unalias(t).(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("enum identifier %s must be a defined interface type", types.TypeString(t, types.RelativeTo(pkg)))
+	}
+	obj := named.Obj()
+	if obj.Pkg() != pkg {
+		return nil, fmt.Errorf("enum identifier %s must be declared in package %s", types.TypeString(t, types.RelativeTo(pkg)), pkg.Name())
+	}
+	if _, ok := named.Underlying().(*types.Interface); !ok {
+		return nil, fmt.Errorf("enum identifier %s must be an interface", obj.Name())
+	}
+	return obj, nil
 }
 
 type enumIdentDefinition struct {
-	ident              *ast.Ident
-	visitorReturnIdent ast.Expr
+	ident          *types.TypeName
+	visitorReturns []types.Type
+	visitorContext bool
+	visitorArgs    []types.Type
 }
 
-func extractEnumIdentDefinition(enumPackage string, spec *ast.TypeSpec) (*enumIdentDefinition, bool) {
-	switch s := spec.Type.(type) {
-	case *ast.InterfaceType:
-		// type A interface { enumPackage.VisitorReturns[Ident] }
-		if ident, ok := findVisitorReturnsFromFields(enumPackage, s); ok {
+func extractEnumIdentDefinition(info *types.Info, spec *ast.TypeSpec) (*enumIdentDefinition, bool) {
+	obj, ok := info.Defs[spec.Name].(*types.TypeName)
+	if !ok || obj.IsAlias() {
+		return nil, false
+	}
+	if i, ok := obj.Type().Underlying().(*types.Interface); ok {
+		// type A interface {
+		// 	enum.VisitorReturns[Ident]
+		// 	enum.VisitorContext
+		// 	enum.VisitorArgs[Ident]
+		// }
+		visitorReturns, hasReturns := findVisitorReturnsFromEmbeddeds(i)
+		visitorContext := findVisitorContextFromEmbeddeds(i)
+		visitorArgs, hasArgs := findVisitorArgsFromEmbeddeds(i)
+		if hasReturns || visitorContext || hasArgs {
 			return &enumIdentDefinition{
-				ident:              spec.Name,
-				visitorReturnIdent: ident,
+				ident:          obj,
+				visitorReturns: visitorReturns,
+				visitorContext: visitorContext,
+				visitorArgs:    visitorArgs,
 			}, true
 		}
 	}
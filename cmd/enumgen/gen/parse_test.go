@@ -0,0 +1,51 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// generateTestdata generates the code of the package in testdata/dir, and returns the code and the path of the file.
+func generateTestdata(t *testing.T, dir string, cfg Config) ([]byte, string, error) {
+	t.Helper()
+	abs, err := filepath.Abs(filepath.Join("testdata", dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Dir = abs
+	files, err := Generate(context.Background(), cfg)
+	filename := filepath.Join(abs, DefaultFilename)
+	return files[filename], filename, err
+}
+
+// Any spelling denoting enum.MemberOf is recognized, and the generated code is the same as the golden file.
+func TestEnumSpellings(t *testing.T) {
+	for _, dir := range []string{"dotimport", "renamed", "alias"} {
+		dir := dir
+		t.Run(dir, func(t *testing.T) {
+			code, filename, err := generateTestdata(t, dir, Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(code, golden) {
+				t.Errorf("generated code differs from %s:\n%s", filename, code)
+			}
+		})
+	}
+}
+
+// The type which only looks like enum.MemberOf is not recognized.
+func TestEnumSpellingShadowed(t *testing.T) {
+	_, _, err := generateTestdata(t, "shadowed", Config{})
+	if !errors.Is(err, ErrNoEnum) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// signature holds parameters and results of visit method and accept method, common to all members of the enum.
+//
+//	VisitA(ctx context.Context, e A, arg0 T0, arg1 T1) R
+//	Accept(ctx context.Context, v ExampleVisitor, arg0 T0, arg1 T1) R
+type signature struct {
+	context ast.Expr   // type of context parameter(context.Context), nil if not required
+	args    []ast.Expr // types of additional parameters
+	results []ast.Expr // result types
+
+	returnsError bool // whether the last result type is error
+}
+
+func argName(i int) *ast.Ident {
+	return ast.NewIdent(fmt.Sprintf("arg%d", i))
+}
+
+// params returns parameter list of the method, whose principal parameter is p(e.g. `e A`, `v ExampleVisitor`).
+func (s *signature) params(p *ast.Field) *ast.FieldList {
+	var list []*ast.Field
+	if s.context != nil {
+		list = append(list, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent("ctx"),
+			},
+			Type: s.context,
+		})
+	}
+	list = append(list, p)
+	for i, t := range s.args {
+		list = append(list, &ast.Field{
+			Names: []*ast.Ident{
+				argName(i),
+			},
+			Type: t,
+		})
+	}
+	return &ast.FieldList{
+		List: list,
+	}
+}
+
+// paramTypes returns unnamed parameter list of the func type, whose principal parameter type is t.
+func (s *signature) paramTypes(t ast.Expr) *ast.FieldList {
+	var list []*ast.Field
+	if s.context != nil {
+		list = append(list, &ast.Field{
+			Type: s.context,
+		})
+	}
+	list = append(list, &ast.Field{
+		Type: t,
+	})
+	for _, t := range s.args {
+		list = append(list, &ast.Field{
+			Type: t,
+		})
+	}
+	return &ast.FieldList{
+		List: list,
+	}
+}
+
+// callArgs returns arguments passed to the method, whose principal argument is arg.
+func (s *signature) callArgs(arg ast.Expr) []ast.Expr {
+	var args []ast.Expr
+	if s.context != nil {
+		args = append(args, ast.NewIdent("ctx"))
+	}
+	args = append(args, arg)
+	for i := range s.args {
+		args = append(args, argName(i))
+	}
+	return args
+}
+
+// resultList returns result list of the method, or nil if the method has no results.
+func (s *signature) resultList() *ast.FieldList {
+	if len(s.results) == 0 {
+		return nil
+	}
+	list := make([]*ast.Field, 0, len(s.results))
+	for _, r := range s.results {
+		list = append(list, &ast.Field{
+			Type: r,
+		})
+	}
+	return &ast.FieldList{
+		List: list,
+	}
+}
+
+// callStmt returns the statement calling the method, which returns its results if any.
+func (s *signature) callStmt(call *ast.CallExpr) ast.Stmt {
+	if len(s.results) == 0 {
+		return &ast.ExprStmt{
+			X: call,
+		}
+	}
+	return &ast.ReturnStmt{
+		Results: []ast.Expr{
+			call,
+		},
+	}
+}
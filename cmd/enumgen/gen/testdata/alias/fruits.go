@@ -0,0 +1,15 @@
+package alias
+
+import "github.com/daichitakahashi/go-enum"
+
+type fruit = enum.MemberOf[Fruits]
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		fruit
+	}
+	Orange struct {
+		fruit
+	}
+)
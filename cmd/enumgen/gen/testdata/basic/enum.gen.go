@@ -0,0 +1,22 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package basic
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple)
+		VisitOrange(e Orange)
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor)
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) {
+	v.VisitApple(e)
+}
+func (e Orange) Accept(v FruitsVisitor) {
+	v.VisitOrange(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Orange{}}
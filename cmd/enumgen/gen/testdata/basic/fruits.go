@@ -0,0 +1,13 @@
+package basic
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+)
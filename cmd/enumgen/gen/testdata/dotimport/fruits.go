@@ -0,0 +1,13 @@
+package dotimport
+
+import . "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		MemberOf[Fruits]
+	}
+	Orange struct {
+		MemberOf[Fruits]
+	}
+)
@@ -0,0 +1,13 @@
+package invalid
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits] `enum:"name=fruit"`
+	}
+	Orange struct {
+		enum.MemberOf[Fruits] `enum:"name=fruit"`
+	}
+)
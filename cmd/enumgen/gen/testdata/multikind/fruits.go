@@ -0,0 +1,12 @@
+package multikind
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Color  interface{}
+	Apple  struct {
+		_ enum.MemberOf[Fruits]
+		_ enum.MemberOf[Color]
+	}
+)
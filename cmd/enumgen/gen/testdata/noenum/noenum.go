@@ -0,0 +1,4 @@
+// Package noenum declares no enum.
+package noenum
+
+type Fruits interface{}
@@ -0,0 +1,13 @@
+package renamed
+
+import goenum "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		goenum.MemberOf[Fruits]
+	}
+	Orange struct {
+		goenum.MemberOf[Fruits]
+	}
+)
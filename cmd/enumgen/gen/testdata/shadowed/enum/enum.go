@@ -0,0 +1,4 @@
+// Package enum only looks like go-enum.
+package enum
+
+type MemberOf[T any] struct{}
@@ -0,0 +1,13 @@
+package shadowed
+
+import "github.com/daichitakahashi/go-enum/cmd/enumgen/gen/testdata/shadowed/enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+)
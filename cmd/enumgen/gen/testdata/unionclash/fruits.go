@@ -0,0 +1,18 @@
+package unionclash
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+// IsFruits is declared by the user, which clashes with the function generated by --union.
+func IsFruits(v Fruits) bool {
+	return v != nil
+}
@@ -0,0 +1,153 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Visitors to be embedded in user-defined visitors, which keep them compiled when members are added to the enum.
+
+func unimplementedSpec(r *namingRegistry, enumIdent string, et *enumTypes) *ast.GenDecl {
+	// type (
+	// 	UnimplementedExampleVisitor struct{}
+	// 	NopExampleVisitor           struct{}
+	// )
+	return &ast.GenDecl{
+		Tok:    token.TYPE,
+		Lparen: 1, // force parenthesized form
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       ast.NewIdent(r.unimplementedTypeName(enumIdent)),
+				TypeParams: et.params(),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						Opening: 1, // print as `struct{}`
+						Closing: 1,
+					},
+				},
+			},
+			&ast.TypeSpec{
+				Name:       ast.NewIdent(r.nopTypeName(enumIdent)),
+				TypeParams: et.params(),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						Opening: 1, // print as `struct{}`
+						Closing: 1,
+					},
+				},
+			},
+		},
+	}
+}
+
+// zeroResults returns declaration of variables holding zero values of the result types, and the variables.
+func zeroResults(results []ast.Expr) (ast.Stmt, []ast.Expr) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	var (
+		specs = make([]ast.Spec, 0, len(results))
+		vars  = make([]ast.Expr, 0, len(results))
+	)
+	for i, t := range results {
+		name := ast.NewIdent(fmt.Sprintf("r%d", i))
+		specs = append(specs, &ast.ValueSpec{
+			Names: []*ast.Ident{
+				name,
+			},
+			Type: t,
+		})
+		vars = append(vars, name)
+	}
+	decl := &ast.GenDecl{
+		Tok:   token.VAR,
+		Specs: specs,
+	}
+	if len(specs) > 1 {
+		decl.Lparen = 1 // force parenthesized form
+	}
+	return &ast.DeclStmt{
+		Decl: decl,
+	}, vars
+}
+
+func unimplementedImpl(r *namingRegistry, enumIdent, enumPkg, fmtPkg string, member *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (UnimplementedExampleVisitor) VisitA(A) (R, error) {
+	// 	var r0 R
+	// 	return r0, fmt.Errorf("ExampleVisitor.VisitA: %w", enum.ErrUnimplemented)
+	// }
+	// or, if the last result is not error
+	// func (UnimplementedExampleVisitor) VisitA(A) R {
+	// 	panic(fmt.Errorf("ExampleVisitor.VisitA: %w", enum.ErrUnimplemented))
+	// }
+
+	var (
+		visitMethod = r.visitMethodName(enumIdent, member.Name)
+		err         = call(
+			selector(ast.NewIdent(fmtPkg), "Errorf"),
+			stringLit(fmt.Sprintf("%s.%s: %%w", r.visitorTypeName(enumIdent), visitMethod)),
+			selector(ast.NewIdent(enumPkg), "ErrUnimplemented"),
+		)
+
+		stmts []ast.Stmt
+	)
+	if sig.returnsError {
+		// zero values except error
+		decl, vars := zeroResults(sig.results[:len(sig.results)-1])
+		if decl != nil {
+			stmts = append(stmts, decl)
+		}
+		stmts = append(stmts, returnStmt(append(vars, err)...))
+	} else {
+		stmts = append(stmts, &ast.ExprStmt{
+			X: call(ast.NewIdent("panic"), err),
+		})
+	}
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("", et.instance(r.unimplementedTypeName(enumIdent))),
+		),
+		Name: ast.NewIdent(visitMethod),
+		Type: &ast.FuncType{
+			Params:  sig.paramTypes(et.member(member)),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
+func nopImpl(r *namingRegistry, enumIdent string, member *ast.Ident, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (NopExampleVisitor) VisitA(A) (R, error) {
+	// 	var r0 R
+	// 	return r0, nil
+	// }
+
+	var stmts []ast.Stmt
+	if sig.returnsError {
+		decl, vars := zeroResults(sig.results[:len(sig.results)-1])
+		if decl != nil {
+			stmts = append(stmts, decl)
+		}
+		stmts = append(stmts, returnStmt(append(vars, ast.NewIdent("nil"))...))
+	} else if decl, vars := zeroResults(sig.results); decl != nil {
+		stmts = append(stmts, decl, returnStmt(vars...))
+	}
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("", et.instance(r.nopTypeName(enumIdent))),
+		),
+		Name: ast.NewIdent(r.visitMethodName(enumIdent, member.Name)),
+		Type: &ast.FuncType{
+			Params:  sig.paramTypes(et.member(member)),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// Union constraint of the members, and narrowing of the enum identifier constrained by it.
+
+func memberConstraintName(enumIdent string) string {
+	return fmt.Sprintf("%sMember", enumIdent)
+}
+
+func isFuncName(enumIdent string) string {
+	return fmt.Sprintf("Is%s", enumIdent)
+}
+
+func asFuncName(enumIdent string) string {
+	return fmt.Sprintf("As%s", enumIdent)
+}
+
+// Names of the declarations generated with the union, which must not be declared by the package.
+func unionDeclNames(enumIdent string) []string {
+	return []string{memberConstraintName(enumIdent), isFuncName(enumIdent), asFuncName(enumIdent)}
+}
+
+func memberConstraintSpec(enumIdent string, members []*ast.Ident, et *enumTypes) *ast.TypeSpec {
+	// ExampleMember interface {
+	// 	A | B
+	// }
+
+	var union ast.Expr
+	for _, m := range members {
+		if union == nil {
+			union = et.member(m)
+			continue
+		}
+		union = &ast.BinaryExpr{
+			X:  union,
+			Op: token.OR,
+			Y:  et.member(m),
+		}
+	}
+
+	return &ast.TypeSpec{
+		Name:       ast.NewIdent(memberConstraintName(enumIdent)),
+		TypeParams: et.params(),
+		Type: &ast.InterfaceType{
+			Methods: fieldList(
+				field("", union),
+			),
+		},
+	}
+}
+
+// narrowTypeParams returns type parameters of Is and As functions, the member M followed by type parameters of the enum.
+// M comes first, so that the caller of non-generic enum specifies only M(e.g. `IsFruits[Apple](f)`).
+// Type arguments of generic enum cannot be inferred from the argument of the identifier interface, and follow M(e.g. `IsResult[Ok[int], int](r)`).
+func narrowTypeParams(enumIdent string, et *enumTypes) (*ast.Ident, *ast.FieldList) {
+	m := ast.NewIdent(et.unusedName("M"))
+	list := fieldList(field(m.Name, et.instance(memberConstraintName(enumIdent))))
+	if et.generic() {
+		list.List = append(list.List, et.list...)
+	}
+	return m, list
+}
+
+func isFunc(enumIdent string, et *enumTypes) *ast.FuncDecl {
+	// func IsExample[M ExampleMember](v Example) bool {
+	// 	_, ok := v.(M)
+	// 	return ok
+	// }
+	m, typeParams := narrowTypeParams(enumIdent, et)
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(isFuncName(enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: typeParams,
+			Params: fieldList(
+				field("v", et.instance(enumIdent)),
+			),
+			Results: fieldList(
+				field("", ast.NewIdent("bool")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				define(
+					[]ast.Expr{ast.NewIdent("_"), ast.NewIdent("ok")},
+					&ast.TypeAssertExpr{X: ast.NewIdent("v"), Type: m},
+				),
+				returnStmt(ast.NewIdent("ok")),
+			},
+		},
+	}
+}
+
+func asFunc(enumIdent string, et *enumTypes) *ast.FuncDecl {
+	// func AsExample[M ExampleMember](v Example) (M, bool) {
+	// 	m, ok := v.(M)
+	// 	return m, ok
+	// }
+	m, typeParams := narrowTypeParams(enumIdent, et)
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(asFuncName(enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: typeParams,
+			Params: fieldList(
+				field("v", et.instance(enumIdent)),
+			),
+			Results: fieldList(
+				field("", m),
+				field("", ast.NewIdent("bool")),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				define(
+					[]ast.Expr{ast.NewIdent("m"), ast.NewIdent("ok")},
+					&ast.TypeAssertExpr{X: ast.NewIdent("v"), Type: m},
+				),
+				returnStmt(ast.NewIdent("m"), ast.NewIdent("ok")),
+			},
+		},
+	}
+}
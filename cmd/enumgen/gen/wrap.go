@@ -0,0 +1,141 @@
+package gen
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Visitor decorating each visit method with the middleware, e.g. logging, metrics and panic recovery.
+
+// middlewareType returns the type of middleware function:
+// `func(ctx context.Context, kind string, next func(ctx context.Context) R) R`.
+func middlewareType(sig *signature) *ast.FuncType {
+	var params, nextParams []*ast.Field
+	if sig.context != nil {
+		params = append(params, field("ctx", sig.context))
+		nextParams = append(nextParams, field("ctx", sig.context))
+	}
+	params = append(params,
+		field("kind", ast.NewIdent("string")),
+		field("next", &ast.FuncType{
+			Params:  fieldList(nextParams...),
+			Results: sig.resultList(),
+		}),
+	)
+	return &ast.FuncType{
+		Params:  fieldList(params...),
+		Results: sig.resultList(),
+	}
+}
+
+func wrapFunc(r *namingRegistry, enumIdent string, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func WrapExampleVisitor(v ExampleVisitor, mw func(kind string, next func() R) R) ExampleVisitor {
+	// 	return __WrapExampleVisitor{v: v, mw: mw}
+	// }
+
+	visitorType := et.instance(r.visitorTypeName(enumIdent))
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(r.wrapFuncName(enumIdent)),
+		Type: &ast.FuncType{
+			TypeParams: et.params(),
+			Params: fieldList(
+				field("v", visitorType),
+				field("mw", middlewareType(sig)),
+			),
+			Results: fieldList(
+				field("", visitorType),
+			),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				returnStmt(&ast.CompositeLit{
+					Type: et.instance("__" + r.wrapFuncName(enumIdent)),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent("v"),
+							Value: ast.NewIdent("v"),
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent("mw"),
+							Value: ast.NewIdent("mw"),
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+func wrapSpec(r *namingRegistry, enumIdent string, sig *signature, et *enumTypes) *ast.GenDecl {
+	// type __WrapExampleVisitor struct {
+	// 	v  ExampleVisitor
+	// 	mw func(kind string, next func() R) R
+	// }
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       ast.NewIdent("__" + r.wrapFuncName(enumIdent)),
+				TypeParams: et.params(),
+				Type: &ast.StructType{
+					Fields: fieldList(
+						field("v", et.instance(r.visitorTypeName(enumIdent))),
+						field("mw", middlewareType(sig)),
+					),
+				},
+			},
+		},
+	}
+}
+
+// The kind passed to the middleware is the member name(see memberName), which may differ from the type name.
+func wrapImpl(r *namingRegistry, enumIdent string, member *ast.Ident, kind string, sig *signature, et *enumTypes) *ast.FuncDecl {
+	// func (w __WrapExampleVisitor) VisitA(e A) R {
+	// 	return w.mw("A", func() R {
+	// 		return w.v.VisitA(e)
+	// 	})
+	// }
+
+	var (
+		w           = ast.NewIdent("w")
+		visitMethod = r.visitMethodName(enumIdent, member.Name)
+
+		nextParams []*ast.Field
+		mwArgs     []ast.Expr
+	)
+	if sig.context != nil {
+		// middleware may replace the context passed to the visitor
+		nextParams = append(nextParams, field("ctx", sig.context))
+		mwArgs = append(mwArgs, ast.NewIdent("ctx"))
+	}
+	mwArgs = append(mwArgs,
+		stringLit(kind),
+		&ast.FuncLit{
+			Type: &ast.FuncType{
+				Params:  fieldList(nextParams...),
+				Results: sig.resultList(),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					sig.callStmt(call(selector(selector(w, "v"), visitMethod), sig.callArgs(ast.NewIdent("e"))...)),
+				},
+			},
+		},
+	)
+
+	return &ast.FuncDecl{
+		Recv: fieldList(
+			field("w", et.instance("__"+r.wrapFuncName(enumIdent))),
+		),
+		Name: ast.NewIdent(visitMethod),
+		Type: &ast.FuncType{
+			Params:  sig.params(field("e", et.member(member))),
+			Results: sig.resultList(),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				sig.callStmt(call(selector(w, "mw"), mwArgs...)),
+			},
+		},
+	}
+}
@@ -1,7 +1,38 @@
 package enum
 
+import "errors"
+
+// ErrUnimplemented is returned(or panicked) by visit methods of generated unimplemented visitor,
+// for members not handled by the embedding visitor.
+var ErrUnimplemented = errors.New("enum: visit method not implemented")
+
 // MemberOf marks membership of EnumIdent on struct.
 type MemberOf[EnumIdent any] struct{}
 
 // VisitorReturns specifies return type of visitor on enum identifier.
 type VisitorReturns[Return any] interface{}
+
+// VisitorReturns2 specifies two return types of visitor on enum identifier(e.g. `VisitorReturns2[T, error]`).
+type VisitorReturns2[Return1, Return2 any] interface{}
+
+// VisitorReturns3 specifies three return types of visitor on enum identifier.
+type VisitorReturns3[Return1, Return2, Return3 any] interface{}
+
+// VisitorContext specifies visitor and accept methods on enum identifier to take context.Context as the first parameter.
+type VisitorContext interface{}
+
+// VisitorArgs specifies additional parameter type of visitor and accept methods on enum identifier.
+type VisitorArgs[A any] interface{}
+
+// VisitorArgs2 specifies two additional parameter types of visitor and accept methods on enum identifier.
+type VisitorArgs2[A1, A2 any] interface{}
+
+// VisitorArgs3 specifies three additional parameter types of visitor and accept methods on enum identifier.
+type VisitorArgs3[A1, A2, A3 any] interface{}
+
+// SubEnumOf marks membership of EnumIdent on another enum identifier interface, which nests the enum.
+//
+//	type OrderEvent interface {
+//		enum.SubEnumOf[Event]
+//	}
+type SubEnumOf[EnumIdent any] interface{}
@@ -0,0 +1,223 @@
+// Package enumcheck provides an analyzer which reports type switches over enum identifiers not covering all members.
+package enumcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/daichitakahashi/go-enum/internal/enumtype"
+)
+
+const doc = `check exhaustiveness of type switches over enum identifiers
+
+The enum identifier is an interface which has members declared with enum.MemberOf.
+A type switch on the value of the enum identifier must have cases of all members, or default case.
+
+With -sealed flag, it also reports enum identifiers which are not sealed.
+Sealed enum identifier has an unexported method(e.g. embedding XEnum generated by enumgen --sealed),
+so the types declared outside of the package cannot satisfy it.`
+
+var checkSealed bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkSealed, "sealed", false, "report enum identifiers which are not sealed")
+}
+
+// Analyzer reports type switches over enum identifiers which neither cover all members nor have default case.
+var Analyzer = &analysis.Analyzer{
+	Name:      "enumcheck",
+	Doc:       doc,
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(Members)},
+}
+
+// Members is a fact exported for enum identifiers, which holds the names of its members in declaration order.
+type Members struct {
+	Names []string
+}
+
+// AFact implements analysis.Fact.
+func (*Members) AFact() {}
+
+func (m *Members) String() string {
+	return "members(" + strings.Join(m.Names, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// collect members of enum identifiers declared in this package
+	members := map[*types.TypeName]*Members{}
+	var idents []*types.TypeName
+	inspect.Preorder([]ast.Node{(*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		spec := n.(*ast.TypeSpec)
+		for _, member := range enumtype.MemberOf(pass.TypesInfo, spec) {
+			named, ok := enumtype.Unalias(member.EnumIdent).(*types.Named)
+			if !ok || named.Obj().Pkg() != pass.Pkg {
+				// invalid enum identifier is reported by enumgen
+				continue
+			}
+			obj := named.Obj()
+			m, ok := members[obj]
+			if !ok {
+				m = &Members{}
+				members[obj] = m
+				idents = append(idents, obj)
+			}
+			m.Names = append(m.Names, spec.Name.Name)
+		}
+	})
+	for _, obj := range idents {
+		pass.ExportObjectFact(obj, members[obj])
+		if checkSealed && !sealed(obj) {
+			pass.Reportf(obj.Pos(), "enum identifier %s is not sealed", obj.Name())
+		}
+	}
+
+	inspect.Preorder([]ast.Node{(*ast.TypeSwitchStmt)(nil)}, func(n ast.Node) {
+		stmt := n.(*ast.TypeSwitchStmt)
+		var x ast.Expr
+		switch a := stmt.Assign.(type) {
+		case *ast.AssignStmt:
+			// switch v := x.(type)
+			x = a.Rhs[0].(*ast.TypeAssertExpr).X
+		case *ast.ExprStmt:
+			// switch x.(type)
+			x = a.X.(*ast.TypeAssertExpr).X
+		}
+		named, ok := enumtype.Unalias(pass.TypesInfo.TypeOf(x)).(*types.Named)
+		if !ok {
+			return
+		}
+		obj := named.Origin().Obj()
+
+		// memberNames returns the names of members of the enum identifier declared in this package or imported.
+		memberNames := func(enumIdent *types.TypeName) ([]string, bool) {
+			if m, ok := members[enumIdent]; ok {
+				return m.Names, true
+			}
+			if m := new(Members); pass.ImportObjectFact(enumIdent, m) {
+				return m.Names, true
+			}
+			return nil, false
+		}
+		if _, ok := memberNames(obj); !ok {
+			return
+		}
+
+		var cases []types.Type
+		for _, c := range stmt.Body.List {
+			clause := c.(*ast.CaseClause)
+			if clause.List == nil {
+				// default case
+				return
+			}
+			for _, expr := range clause.List {
+				if t := pass.TypesInfo.TypeOf(expr); t != nil {
+					cases = append(cases, t)
+				}
+			}
+		}
+
+		missing := missingMembers(obj, cases, memberNames, map[*types.TypeName]bool{})
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			pass.Reportf(stmt.Pos(), "missing cases in type switch of %s: %s", types.TypeString(named, qualifier(pass.Pkg)), strings.Join(missing, ", "))
+		}
+	})
+	return nil, nil
+}
+
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+}
+
+// Report whether the enum identifier has unexported method, which cannot be implemented outside of the package.
+func sealed(enumIdent *types.TypeName) bool {
+	i, ok := enumIdent.Type().Underlying().(*types.Interface)
+	if !ok {
+		return false
+	}
+	for n := 0; n < i.NumMethods(); n++ {
+		if !i.Method(n).Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the names of members of the enum identifier not matched by any of cases.
+// Members are identified by the names in Members fact, because unexported members of imported package may be absent
+// from the scope of the package loaded from export data. Such a member can be matched only by the case of its name.
+// Nested enum(enum.SubEnumOf) is covered if it is matched by the case, or all of its own members are covered,
+// otherwise its members not covered are reported.
+func missingMembers(enumIdent *types.TypeName, cases []types.Type, memberNames func(*types.TypeName) ([]string, bool), visiting map[*types.TypeName]bool) []string {
+	visiting[enumIdent] = true
+	defer delete(visiting, enumIdent)
+
+	pkg := enumIdent.Pkg()
+	named := map[string]bool{} // names of the types of cases declared in the package of enum identifier
+	for _, c := range cases {
+		if o := origin(c); o != nil && o.Pkg() == pkg {
+			named[o.Name()] = true
+		}
+	}
+
+	names, _ := memberNames(enumIdent)
+	var missing []string
+	for _, name := range names {
+		if named[name] {
+			continue
+		}
+		member, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if covered(member.Type(), cases) {
+			continue
+		}
+		if _, nested := memberNames(member); nested && !visiting[member] {
+			missing = append(missing, missingMembers(member, cases, memberNames, visiting)...)
+			continue
+		}
+		missing = append(missing, name)
+	}
+	return missing
+}
+
+// Report whether the member is matched by any of cases of interface type implemented by the member.
+func covered(member types.Type, cases []types.Type) bool {
+	ptr := types.NewPointer(member)
+	for _, c := range cases {
+		if i, ok := c.Underlying().(*types.Interface); ok {
+			if types.Implements(member, i) || types.Implements(ptr, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Return the declared type name of the (pointer to) named type, which is the same for all instances of generic type.
+func origin(t types.Type) *types.TypeName {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := enumtype.Unalias(t).(*types.Named); ok {
+		return named.Origin().Obj()
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package enumcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/daichitakahashi/go-enum/enumcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), enumcheck.Analyzer, "a", "nested")
+}
+
+func TestAnalyzerSealed(t *testing.T) {
+	if err := enumcheck.Analyzer.Flags.Set("sealed", "true"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = enumcheck.Analyzer.Flags.Set("sealed", "false")
+	})
+	analysistest.Run(t, analysistest.TestData(), enumcheck.Analyzer, "sealed")
+}
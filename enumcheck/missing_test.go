@@ -0,0 +1,43 @@
+package enumcheck
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+// The package loaded from export data may lack unexported members of the enum in its scope.
+func TestMissingMembersAbsentFromScope(t *testing.T) {
+	pkg := types.NewPackage("example.com/b", "b")
+	newType := func(name string, underlying types.Type) *types.TypeName {
+		obj := types.NewTypeName(token.NoPos, pkg, name, nil)
+		types.NewNamed(obj, underlying, nil)
+		return obj
+	}
+	enumIdent := newType("Token", types.NewInterfaceType(nil, nil).Complete())
+	word := newType("Word", types.NewStruct(nil, nil))
+	pkg.Scope().Insert(enumIdent)
+	pkg.Scope().Insert(word)
+	// unexported member "space" is not inserted
+
+	memberNames := func(ident *types.TypeName) ([]string, bool) {
+		if ident == enumIdent {
+			return []string{"Word", "space"}, true
+		}
+		return nil, false
+	}
+	for _, c := range []struct {
+		cases []types.Type
+		want  []string
+	}{
+		{nil, []string{"Word", "space"}},
+		{[]types.Type{word.Type()}, []string{"space"}},
+		{[]types.Type{types.NewPointer(word.Type())}, []string{"space"}},
+	} {
+		got := missingMembers(enumIdent, c.cases, memberNames, map[*types.TypeName]bool{})
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("cases %v: got %q, want %q", c.cases, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package a
+
+import (
+	"b"
+	"nested"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+type (
+	Fruits interface{} // want Fruits:`members\(Apple, Orange, Grape\)`
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+	Grape struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+func (Orange) Sour() {}
+func (Grape) Sour()  {}
+
+func missing(f Fruits) {
+	switch f.(type) { // want "missing cases in type switch of Fruits: Grape, Orange"
+	case Apple:
+	}
+}
+
+func complete(f Fruits) {
+	switch f := f.(type) {
+	case Apple:
+	case Orange, Grape:
+		_ = f
+	}
+}
+
+func pointerCase(f Fruits) {
+	switch f.(type) {
+	case Apple, *Orange, Grape:
+	}
+}
+
+func defaultCase(f Fruits) {
+	switch f.(type) {
+	case Apple:
+	default:
+	}
+}
+
+func interfaceCase(f Fruits) {
+	switch f.(type) {
+	case Apple:
+	case interface{ Sour() }:
+	}
+	switch f.(type) { // want "missing cases in type switch of Fruits: Apple"
+	case interface{ Sour() }:
+	}
+}
+
+func notEnum(v any) {
+	switch v.(type) {
+	case Apple:
+	}
+}
+
+type (
+	Result[T any] interface{} // want Result:`members\(Ok, Err\)`
+	Ok[T any]     struct {
+		enum.MemberOf[Result[T]]
+		V T
+	}
+	Err[T any] struct {
+		enum.MemberOf[Result[T]]
+	}
+)
+
+func generic(r Result[int]) {
+	switch r.(type) { // want "missing cases in type switch of Result\\[int\\]: Err"
+	case Ok[int]:
+	}
+	switch r.(type) {
+	case Ok[int], Err[int]:
+	}
+}
+
+func crossPackage(s b.Shape) {
+	switch s.(type) { // want "missing cases in type switch of b.Shape: Square"
+	case b.Circle:
+	}
+	switch s.(type) {
+	case b.Circle, b.Square:
+	}
+}
+
+func crossPackageNested(e nested.Event) {
+	switch e.(type) { // want "missing cases in type switch of nested.Event: Cancelled"
+	case nested.Placed, nested.Login:
+	}
+	switch e.(type) {
+	case nested.Placed, nested.Cancelled, nested.Login:
+	}
+}
+
+func crossPackageUnexported(t b.Token) {
+	switch t.(type) { // want "missing cases in type switch of b.Token: space"
+	case b.Word:
+	}
+	switch t.(type) {
+	case b.Word, b.Blank:
+	}
+	switch t.(type) {
+	case b.Word:
+	default:
+	}
+}
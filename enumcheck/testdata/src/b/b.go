@@ -0,0 +1,37 @@
+package b
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Shape interface { // want Shape:`members\(Circle, Square\)`
+		Area() int
+	}
+	Circle struct {
+		enum.MemberOf[Shape]
+		R int
+	}
+	Square struct {
+		enum.MemberOf[Shape]
+		L int
+	}
+)
+
+func (c Circle) Area() int { return 3 * c.R * c.R }
+func (s Square) Area() int { return s.L * s.L }
+
+type (
+	Token interface{} // want Token:`members\(Word, space\)`
+	Word  struct {
+		enum.MemberOf[Token]
+	}
+	space struct {
+		enum.MemberOf[Token]
+	}
+)
+
+// Blank is implemented by the unexported member.
+type Blank interface {
+	blank()
+}
+
+func (space) blank() {}
@@ -0,0 +1,6 @@
+// Package enum is the stub of github.com/daichitakahashi/go-enum for testing.
+package enum
+
+type MemberOf[EnumIdent any] struct{}
+
+type SubEnumOf[EnumIdent any] interface{}
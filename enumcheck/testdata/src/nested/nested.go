@@ -0,0 +1,50 @@
+package nested
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Event      interface{} // want Event:`members\(OrderEvent, Login\)`
+	OrderEvent interface { // want OrderEvent:`members\(Placed, Cancelled\)`
+		enum.SubEnumOf[Event]
+	}
+	Placed struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Cancelled struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Login struct {
+		enum.MemberOf[Event]
+	}
+)
+
+func leaves(e Event) {
+	switch e.(type) {
+	case Placed, Cancelled, Login:
+	}
+}
+
+func nestedCase(e Event) {
+	switch e.(type) {
+	case Login:
+	case OrderEvent:
+	}
+}
+
+func missingLeaf(e Event) {
+	switch e.(type) { // want "missing cases in type switch of Event: Cancelled"
+	case Placed, Login:
+	}
+}
+
+func missingNested(e Event) {
+	switch e.(type) { // want "missing cases in type switch of Event: Cancelled, Placed"
+	case Login:
+	}
+}
+
+func subEnum(e OrderEvent) {
+	switch e.(type) { // want "missing cases in type switch of OrderEvent: Placed"
+	case Cancelled:
+	}
+}
@@ -0,0 +1,21 @@
+package sealed
+
+import "github.com/daichitakahashi/go-enum"
+
+type (
+	Sealed interface { // want Sealed:`members\(A\)`
+		sealed()
+	}
+	A struct {
+		enum.MemberOf[Sealed]
+	}
+)
+
+func (A) sealed() {}
+
+type (
+	Open interface{} // want Open:`members\(B\)` "enum identifier Open is not sealed"
+	B    struct {
+		enum.MemberOf[Open]
+	}
+)
@@ -2,6 +2,13 @@
 
 package event
 
+import (
+	"context"
+	"fmt"
+
+	enum "github.com/daichitakahashi/go-enum"
+)
+
 type (
 	EventHandler interface {
 		OnOrderPlaced(e OrderPlaced) error
@@ -43,3 +50,40 @@ func (v __EventHandler) OnPaymentReceived(e PaymentReceived) error {
 func (v __EventHandler) OnItemShipped(e ItemShipped) error {
 	return v.__OnItemShipped(e)
 }
+
+type EventBus struct {
+	bus *enum.Bus
+}
+
+func NewEventBus(opts ...enum.BusOption) *EventBus {
+	return &EventBus{bus: enum.NewBus(opts...)}
+}
+func (b *EventBus) SubscribeOrderPlaced(fn func(e OrderPlaced) error) (unsubscribe func()) {
+	return b.bus.Subscribe("OrderPlaced", func(_ context.Context, v any) error {
+		return fn(v.(OrderPlaced))
+	})
+}
+func (b *EventBus) SubscribePaymentReceived(fn func(e PaymentReceived) error) (unsubscribe func()) {
+	return b.bus.Subscribe("PaymentReceived", func(_ context.Context, v any) error {
+		return fn(v.(PaymentReceived))
+	})
+}
+func (b *EventBus) SubscribeItemShipped(fn func(e ItemShipped) error) (unsubscribe func()) {
+	return b.bus.Subscribe("ItemShipped", func(_ context.Context, v any) error {
+		return fn(v.(ItemShipped))
+	})
+}
+func (b *EventBus) Publish(ctx context.Context, e EventEnum) error {
+	switch e.(type) {
+	case OrderPlaced:
+		return b.bus.Publish(ctx, "OrderPlaced", e)
+	case PaymentReceived:
+		return b.bus.Publish(ctx, "PaymentReceived", e)
+	case ItemShipped:
+		return b.bus.Publish(ctx, "ItemShipped", e)
+	}
+	return fmt.Errorf("unexpected EventEnum: %T", e)
+}
+func (b *EventBus) Close() error {
+	return b.bus.Close()
+}
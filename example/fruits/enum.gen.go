@@ -35,11 +35,11 @@ func NewFruitsVisitor(__VisitApple func(e Apple), __VisitOrange func(e Orange),
 	return &__FruitsVisitor{__VisitApple: __VisitApple, __VisitOrange: __VisitOrange, __VisitGrape: __VisitGrape}
 }
 func (v __FruitsVisitor) VisitApple(e Apple) {
-	v.VisitApple(e)
+	v.__VisitApple(e)
 }
 func (v __FruitsVisitor) VisitOrange(e Orange) {
-	v.VisitOrange(e)
+	v.__VisitOrange(e)
 }
 func (v __FruitsVisitor) VisitGrape(e Grape) {
-	v.VisitGrape(e)
+	v.__VisitGrape(e)
 }
@@ -0,0 +1,25 @@
+package fruits
+
+import "testing"
+
+// Visitor created by NewFruitsVisitor must call the given funcs, instead of its own visit methods recursively.
+func TestNewFruitsVisitor(t *testing.T) {
+	var visited []string
+	v := NewFruitsVisitor(
+		func(e Apple) { visited = append(visited, "Apple") },
+		func(e Orange) { visited = append(visited, "Orange") },
+		func(e Grape) { visited = append(visited, "Grape") },
+	)
+	Apple{}.Accept(v)
+	Grape{}.Accept(v)
+	Orange{}.Accept(v)
+
+	if got, want := len(visited), 3; got != want {
+		t.Fatalf("unexpected number of visits: %d", got)
+	}
+	for i, want := range []string{"Apple", "Grape", "Orange"} {
+		if visited[i] != want {
+			t.Errorf("visit %d: got %s, want %s", i, visited[i], want)
+		}
+	}
+}
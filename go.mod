@@ -1,16 +1,16 @@
 module github.com/daichitakahashi/go-enum
 
-go 1.20
+go 1.22.0
 
 require (
 	github.com/IGLOU-EU/go-wildcard v1.0.3
 	github.com/spf13/cobra v1.7.0
-	golang.org/x/tools v0.11.0
+	golang.org/x/tools v0.30.0
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/mod v0.12.0 // indirect
-	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 )
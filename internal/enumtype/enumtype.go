@@ -0,0 +1,155 @@
+// Package enumtype resolves the symbols of go-enum package with type information.
+package enumtype
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+const (
+	PackagePath          = "github.com/daichitakahashi/go-enum"
+	MemberOfSymbol       = "MemberOf"
+	SubEnumOfSymbol      = "SubEnumOf"
+	VisitorContextSymbol = "VisitorContext"
+)
+
+var (
+	// VisitorReturnsSymbols are the symbols specifying return types of visitor methods.
+	VisitorReturnsSymbols = []string{"VisitorReturns", "VisitorReturns2", "VisitorReturns3"}
+	// VisitorArgsSymbols are the symbols specifying additional parameters of visitor methods.
+	VisitorArgsSymbols = []string{"VisitorArgs", "VisitorArgs2", "VisitorArgs3"}
+)
+
+// IsSymbol reports whether obj is the symbol declared in go-enum package.
+func IsSymbol(obj types.Object, symbol string) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == PackagePath && obj.Name() == symbol
+}
+
+// Is reports whether t is the type of the symbol declared in go-enum package.
+func Is(symbol string, t types.Type) bool {
+	named, ok := Unalias(t).(*types.Named)
+	return ok && IsSymbol(named.Origin().Obj(), symbol)
+}
+
+// TypeArgs returns type arguments of the instantiated type `MemberOf[T]`(or another symbol of go-enum).
+// Any spelling of the type(dot import, renamed import, alias) is accepted because t is already resolved by type checker.
+func TypeArgs(symbol string, t types.Type) ([]types.Type, bool) {
+	named, ok := Unalias(t).(*types.Named)
+	if !ok || !IsSymbol(named.Origin().Obj(), symbol) {
+		return nil, false
+	}
+	args := named.TypeArgs()
+	list := make([]types.Type, 0, args.Len())
+	for i := 0; i < args.Len(); i++ {
+		list = append(list, args.At(i))
+	}
+	return list, true
+}
+
+// TypeArg returns type argument T of the instantiated type `MemberOf[T]`(or another symbol of go-enum).
+func TypeArg(symbol string, t types.Type) (types.Type, bool) {
+	args, ok := TypeArgs(symbol, t)
+	if !ok || len(args) != 1 {
+		return nil, false
+	}
+	return args[0], true
+}
+
+// TypeArgFromExpr returns type argument T from the expression `MemberOf[T]`.
+// If the expression refers the symbol but type checking is failed(e.g. T is undefined), it returns invalid type.
+func TypeArgFromExpr(info *types.Info, symbol string, expr ast.Expr) (types.Type, bool) {
+	if t, ok := TypeArg(symbol, info.TypeOf(expr)); ok {
+		return t, true
+	}
+	if index, ok := expr.(*ast.IndexExpr); ok {
+		var ident *ast.Ident
+		switch x := index.X.(type) {
+		case *ast.Ident:
+			ident = x
+		case *ast.SelectorExpr:
+			ident = x.Sel
+		}
+		if ident != nil && IsSymbol(info.Uses[ident], symbol) {
+			return types.Typ[types.Invalid], true
+		}
+	}
+	return nil, false
+}
+
+// Member is the declaration of the enum member.
+type Member struct {
+	EnumIdent types.Type    // enum identifier T
+	Expr      ast.Expr      // expression of `enum.MemberOf[T]`
+	Tag       *ast.BasicLit // tag of the field, nil if not tagged
+	Nested    bool          // whether the member is the enum identifier nested by `enum.SubEnumOf[T]`
+}
+
+// MemberOf returns the members declared as `type A struct { enum.MemberOf[T] }` or `type A enum.MemberOf[T]`,
+// and the nested enum identifier declared as `type A interface { enum.SubEnumOf[T] }`.
+// A struct can join multiple enums with blank fields(e.g. `_ enum.MemberOf[T1]; _ enum.MemberOf[T2]`),
+// because embedded fields of MemberOf conflict with each other.
+func MemberOf(info *types.Info, spec *ast.TypeSpec) []*Member {
+	if spec.Assign.IsValid() {
+		// methods cannot be declared on alias
+		return nil
+	}
+	switch s := spec.Type.(type) {
+	case *ast.StructType:
+		// type A struct {
+		// 	enum.MemberOf[Ident] `enum:"name=a"`
+		// 	_ enum.MemberOf[Ident2]
+		// }
+		var members []*Member
+		for _, f := range s.Fields.List {
+			if !isEmbeddedOrBlank(f) {
+				continue
+			}
+			if enumIdent, ok := TypeArgFromExpr(info, MemberOfSymbol, f.Type); ok {
+				members = append(members, &Member{
+					EnumIdent: enumIdent,
+					Expr:      f.Type,
+					Tag:       f.Tag,
+				})
+			}
+		}
+		return members
+	case *ast.InterfaceType:
+		// type A interface {
+		// 	enum.SubEnumOf[Ident]
+		// }
+		var members []*Member
+		for _, f := range s.Methods.List {
+			if len(f.Names) > 0 {
+				continue
+			}
+			if enumIdent, ok := TypeArgFromExpr(info, SubEnumOfSymbol, f.Type); ok {
+				members = append(members, &Member{
+					EnumIdent: enumIdent,
+					Expr:      f.Type,
+					Nested:    true,
+				})
+			}
+		}
+		return members
+	default:
+		// type A enum.MemberOf[Ident]
+		if enumIdent, ok := TypeArgFromExpr(info, MemberOfSymbol, spec.Type); ok {
+			return []*Member{
+				{
+					EnumIdent: enumIdent,
+					Expr:      spec.Type,
+				},
+			}
+		}
+	}
+	return nil
+}
+
+func isEmbeddedOrBlank(f *ast.Field) bool {
+	for _, name := range f.Names {
+		if name.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
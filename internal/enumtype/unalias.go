@@ -0,0 +1,8 @@
+package enumtype
+
+import "go/types"
+
+// Unalias returns the actual type denoted by the alias t.
+func Unalias(t types.Type) types.Type {
+	return types.Unalias(t)
+}
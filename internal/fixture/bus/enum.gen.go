@@ -0,0 +1,59 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package bus
+
+import (
+	"context"
+	"fmt"
+
+	enum "github.com/daichitakahashi/go-enum"
+)
+
+type (
+	TaskVisitor interface {
+		VisitStarted(ctx context.Context, e Started) error
+		VisitFinished(ctx context.Context, e Finished) error
+	}
+	TaskEnum interface {
+		Accept(ctx context.Context, v TaskVisitor) error
+	}
+)
+
+func (e Started) Accept(ctx context.Context, v TaskVisitor) error {
+	return v.VisitStarted(ctx, e)
+}
+func (e Finished) Accept(ctx context.Context, v TaskVisitor) error {
+	return v.VisitFinished(ctx, e)
+}
+
+var _ = []TaskEnum{Started{}, Finished{}}
+
+type TaskBus struct {
+	bus *enum.Bus
+}
+
+func NewTaskBus(opts ...enum.BusOption) *TaskBus {
+	return &TaskBus{bus: enum.NewBus(opts...)}
+}
+func (b *TaskBus) SubscribeStarted(fn func(ctx context.Context, e Started) error) (unsubscribe func()) {
+	return b.bus.Subscribe("started", func(ctx context.Context, v any) error {
+		return fn(ctx, v.(Started))
+	})
+}
+func (b *TaskBus) SubscribeFinished(fn func(ctx context.Context, e Finished) error) (unsubscribe func()) {
+	return b.bus.Subscribe("finished", func(ctx context.Context, v any) error {
+		return fn(ctx, v.(Finished))
+	})
+}
+func (b *TaskBus) Publish(ctx context.Context, e TaskEnum) error {
+	switch e.(type) {
+	case Started:
+		return b.bus.Publish(ctx, "started", e)
+	case Finished:
+		return b.bus.Publish(ctx, "finished", e)
+	}
+	return fmt.Errorf("unexpected TaskEnum: %T", e)
+}
+func (b *TaskBus) Close() error {
+	return b.bus.Close()
+}
@@ -0,0 +1,21 @@
+// Package bus is the fixture of the in-process bus of the enum whose visitor takes context.
+package bus
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --bus="*"
+
+type (
+	Task interface {
+		enum.VisitorContext
+		enum.VisitorReturns[error]
+	}
+	Started struct {
+		enum.MemberOf[Task] `enum:"name=started"`
+		ID                  int
+	}
+	Finished struct {
+		enum.MemberOf[Task] `enum:"name=finished"`
+		ID                  int
+	}
+)
@@ -0,0 +1,78 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+type ctxKey struct{}
+
+func TestTaskBusContext(t *testing.T) {
+	for name, opts := range map[string][]enum.BusOption{
+		"sync":  nil,
+		"async": {enum.AsyncDelivery(1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := NewTaskBus(opts...)
+
+			got := make(chan string, 2)
+			b.SubscribeStarted(func(ctx context.Context, e Started) error {
+				got <- ctx.Value(ctxKey{}).(string)
+				return nil
+			})
+			b.SubscribeFinished(func(ctx context.Context, e Finished) error {
+				got <- ctx.Value(ctxKey{}).(string)
+				return nil
+			})
+
+			ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+			for _, e := range []TaskEnum{Started{ID: 1}, Finished{ID: 1}} {
+				if err := b.Publish(ctx, e); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			close(got)
+			var n int
+			for v := range got {
+				if v != "value" {
+					t.Errorf("subscriber received unexpected context value: %q", v)
+				}
+				n++
+			}
+			if n != 2 {
+				t.Errorf("delivered %d values, want 2", n)
+			}
+		})
+	}
+}
+
+func TestTaskBusCanceled(t *testing.T) {
+	b := NewTaskBus()
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var called int
+	b.SubscribeStarted(func(ctx context.Context, e Started) error {
+		called++
+		cancel()
+		return nil
+	})
+	b.SubscribeStarted(func(ctx context.Context, e Started) error {
+		called++
+		return nil
+	})
+	if err := b.Publish(ctx, Started{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if called != 1 {
+		t.Errorf("%d subscribers called, want 1", called)
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package byname
+
+import "fmt"
+
+type (
+	ShapeVisitor interface {
+		VisitCircle(e *Circle)
+		VisitRect(e *Rect)
+		VisitPoint(e *Point)
+	}
+	ShapeEnum interface {
+		Accept(v ShapeVisitor)
+	}
+)
+
+func (e *Circle) Accept(v ShapeVisitor) {
+	v.VisitCircle(e)
+}
+func (e *Rect) Accept(v ShapeVisitor) {
+	v.VisitRect(e)
+}
+func (e *Point) Accept(v ShapeVisitor) {
+	v.VisitPoint(e)
+}
+
+var _ = []ShapeEnum{&Circle{}, &Rect{}, &Point{}}
+
+func __newShapeCircle() Shape {
+	return &Circle{}
+}
+func __newShapeRect() Shape {
+	return &Rect{}
+}
+func __newShapePoint() Shape {
+	return &Point{}
+}
+
+var __ShapeFactories = map[string]func() Shape{"circle": __newShapeCircle, "round": __newShapeCircle, "rect": __newShapeRect, "point": __newShapePoint}
+
+func NewShapeByName(name string) (Shape, error) {
+	f, ok := __ShapeFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown name of Shape: %q", name)
+	}
+	return f(), nil
+}
+func ShapeNames() []string {
+	return []string{"circle", "rect", "point"}
+}
@@ -0,0 +1,21 @@
+// Package byname is the fixture of enumgen --by-name.
+package byname
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --by-name="*" --pointer="*"
+
+type (
+	Shape  interface{}
+	Circle struct {
+		enum.MemberOf[Shape] `enum:"name=circle,alias=round"`
+		R                    int
+	}
+	Rect struct {
+		enum.MemberOf[Shape] `enum:"name=rect"`
+		W, H                 int
+	}
+	Point struct {
+		enum.MemberOf[Shape] `enum:"name=point"`
+	}
+)
@@ -0,0 +1,61 @@
+package byname
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decode decodes the JSON object into the member chosen by its name, as decoders of any codec do.
+func decode(name string, data []byte) (Shape, error) {
+	v, err := NewShapeByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func TestNewShapeByName(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		data string
+		want Shape
+	}{
+		{"circle", `{"R":3}`, &Circle{R: 3}},
+		{"round", `{"R":3}`, &Circle{R: 3}},
+		{"rect", `{"W":2,"H":4}`, &Rect{W: 2, H: 4}},
+		{"point", `{}`, &Point{}},
+	} {
+		got, err := decode(c.name, []byte(c.data))
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %#v, want %#v", c.name, got, c.want)
+		}
+	}
+
+	// each call returns the fresh member
+	a, _ := NewShapeByName("circle")
+	b, _ := NewShapeByName("circle")
+	if a == b {
+		t.Error("factory returns the same member")
+	}
+
+	for _, name := range []string{"square", "Circle", ""} {
+		if _, err := NewShapeByName(name); err == nil {
+			t.Errorf("%q: error expected", name)
+		}
+	}
+}
+
+func TestShapeNames(t *testing.T) {
+	want := []string{"circle", "rect", "point"}
+	if got := ShapeNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	enum "github.com/daichitakahashi/go-enum"
+)
+
+type (
+	ResultVisitor[T any] interface {
+		VisitOk(e Ok[T]) error
+		VisitErr(e Err[T]) error
+	}
+	ResultEnum[T any] interface {
+		Accept(v ResultVisitor[T]) error
+	}
+)
+
+func (e Ok[T]) Accept(v ResultVisitor[T]) error {
+	return v.VisitOk(e)
+}
+func (e Err[T]) Accept(v ResultVisitor[T]) error {
+	return v.VisitErr(e)
+}
+func _[T any]() {
+	_ = []ResultEnum[T]{Ok[T]{}, Err[T]{}}
+}
+func MatchResult[T any, R any](e ResultEnum[T], visitOk func(e Ok[T]) R, visitErr func(e Err[T]) R) R {
+	switch e := e.(type) {
+	case Ok[T]:
+		return visitOk(e)
+	case Err[T]:
+		return visitErr(e)
+	}
+	panic(fmt.Sprintf("unexpected ResultEnum: %T", e))
+}
+
+type __ResultVisitor[T any] struct {
+	__VisitOk  func(Ok[T]) error
+	__VisitErr func(Err[T]) error
+}
+
+func NewResultVisitor[T any](__VisitOk func(e Ok[T]) error, __VisitErr func(e Err[T]) error) ResultVisitor[T] {
+	return &__ResultVisitor[T]{__VisitOk: __VisitOk, __VisitErr: __VisitErr}
+}
+func (v __ResultVisitor[T]) VisitOk(e Ok[T]) error {
+	return v.__VisitOk(e)
+}
+func (v __ResultVisitor[T]) VisitErr(e Err[T]) error {
+	return v.__VisitErr(e)
+}
+
+type ResultBus[T any] struct {
+	bus *enum.Bus
+}
+
+func NewResultBus[T any](opts ...enum.BusOption) *ResultBus[T] {
+	return &ResultBus[T]{bus: enum.NewBus(opts...)}
+}
+func (b *ResultBus[T]) SubscribeOk(fn func(e Ok[T]) error) (unsubscribe func()) {
+	return b.bus.Subscribe("ok", func(_ context.Context, v any) error {
+		return fn(v.(Ok[T]))
+	})
+}
+func (b *ResultBus[T]) SubscribeErr(fn func(e Err[T]) error) (unsubscribe func()) {
+	return b.bus.Subscribe("err", func(_ context.Context, v any) error {
+		return fn(v.(Err[T]))
+	})
+}
+func (b *ResultBus[T]) Publish(ctx context.Context, e ResultEnum[T]) error {
+	switch e.(type) {
+	case Ok[T]:
+		return b.bus.Publish(ctx, "ok", e)
+	case Err[T]:
+		return b.bus.Publish(ctx, "err", e)
+	}
+	return fmt.Errorf("unexpected ResultEnum: %T", e)
+}
+func (b *ResultBus[T]) Close() error {
+	return b.bus.Close()
+}
+
+type (
+	EitherVisitor[L any, R any] interface {
+		VisitLeft(e Left[L, R]) string
+		VisitRight(e Right[R, L]) string
+	}
+	EitherEnum[L any, R any] interface {
+		Accept(v EitherVisitor[L, R]) string
+	}
+)
+
+func (e Left[L, R]) Accept(v EitherVisitor[L, R]) string {
+	return v.VisitLeft(e)
+}
+func (e Right[R, L]) Accept(v EitherVisitor[L, R]) string {
+	return v.VisitRight(e)
+}
+func _[L any, R any]() {
+	_ = []EitherEnum[L, R]{Left[L, R]{}, Right[R, L]{}}
+}
+func MatchEither[L any, R any, R1 any](e EitherEnum[L, R], visitLeft func(e Left[L, R]) R1, visitRight func(e Right[R, L]) R1) R1 {
+	switch e := e.(type) {
+	case Left[L, R]:
+		return visitLeft(e)
+	case Right[R, L]:
+		return visitRight(e)
+	}
+	panic(fmt.Sprintf("unexpected EitherEnum: %T", e))
+}
+
+type __EitherVisitor[L any, R any] struct {
+	__VisitLeft  func(Left[L, R]) string
+	__VisitRight func(Right[R, L]) string
+}
+
+func NewEitherVisitor[L any, R any](__VisitLeft func(e Left[L, R]) string, __VisitRight func(e Right[R, L]) string) EitherVisitor[L, R] {
+	return &__EitherVisitor[L, R]{__VisitLeft: __VisitLeft, __VisitRight: __VisitRight}
+}
+func (v __EitherVisitor[L, R]) VisitLeft(e Left[L, R]) string {
+	return v.__VisitLeft(e)
+}
+func (v __EitherVisitor[L, R]) VisitRight(e Right[R, L]) string {
+	return v.__VisitRight(e)
+}
@@ -0,0 +1,35 @@
+// Package generic is the fixture of generic enums, whose members are instantiated with type parameters of the enum.
+package generic
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --match="*" --visitor-impl="*" --bus="Result"
+
+type (
+	Result[T any] interface {
+		enum.VisitorReturns[error]
+	}
+	Ok[T any] struct {
+		enum.MemberOf[Result[T]] `enum:"name=ok"`
+		Value                    T
+	}
+	Err[T any] struct {
+		enum.MemberOf[Result[T]] `enum:"name=err"`
+		Err                      error
+	}
+)
+
+// Either has the member whose type parameters are in the different order from the enum.
+type (
+	Either[L, R any] interface {
+		enum.VisitorReturns[string]
+	}
+	Left[L, R any] struct {
+		enum.MemberOf[Either[L, R]]
+		Value L
+	}
+	Right[R, L any] struct {
+		enum.MemberOf[Either[L, R]]
+		Value R
+	}
+)
@@ -0,0 +1,151 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+var errFailed = errors.New("failed")
+
+func TestResultAccept(t *testing.T) {
+	var got []int
+	v := NewResultVisitor(
+		func(e Ok[int]) error {
+			got = append(got, e.Value)
+			return nil
+		},
+		func(e Err[int]) error {
+			return e.Err
+		},
+	)
+	for _, r := range []ResultEnum[int]{Ok[int]{Value: 1}, Ok[int]{Value: 2}} {
+		if err := r.Accept(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("unexpected values: %v", got)
+	}
+	if err := (Err[int]{Err: errFailed}).Accept(v); !errors.Is(err, errFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMatchResult(t *testing.T) {
+	describe := func(r ResultEnum[string]) string {
+		return MatchResult(r,
+			func(e Ok[string]) string { return "ok:" + e.Value },
+			func(e Err[string]) string { return "err:" + e.Err.Error() },
+		)
+	}
+	for _, c := range []struct {
+		r    ResultEnum[string]
+		want string
+	}{
+		{Ok[string]{Value: "apple"}, "ok:apple"},
+		{Err[string]{Err: errFailed}, "err:failed"},
+	} {
+		if got := describe(c.r); got != c.want {
+			t.Errorf("%T: got %q, want %q", c.r, got, c.want)
+		}
+	}
+}
+
+func TestResultBus(t *testing.T) {
+	for name, opts := range map[string][]enum.BusOption{
+		"sync":  nil,
+		"async": {enum.AsyncDelivery(2)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := NewResultBus[int](opts...)
+
+			var (
+				mu   sync.Mutex
+				sum  int
+				errs []error
+			)
+			b.SubscribeOk(func(e Ok[int]) error {
+				mu.Lock()
+				defer mu.Unlock()
+				sum += e.Value
+				return nil
+			})
+			b.SubscribeErr(func(e Err[int]) error {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, e.Err)
+				return nil
+			})
+
+			ctx := context.Background()
+			for _, r := range []ResultEnum[int]{Ok[int]{Value: 1}, Err[int]{Err: errFailed}, Ok[int]{Value: 2}} {
+				if err := b.Publish(ctx, r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if sum != 3 || len(errs) != 1 || !errors.Is(errs[0], errFailed) {
+				t.Errorf("unexpected deliveries: sum=%d, errs=%v", sum, errs)
+			}
+		})
+	}
+}
+
+// Instances of the bus with different type arguments are independent.
+func TestResultBusInstances(t *testing.T) {
+	ints, strs := NewResultBus[int](), NewResultBus[string]()
+	defer ints.Close()
+	defer strs.Close()
+
+	var got []string
+	ints.SubscribeOk(func(e Ok[int]) error {
+		got = append(got, strconv.Itoa(e.Value))
+		return nil
+	})
+	strs.SubscribeOk(func(e Ok[string]) error {
+		got = append(got, e.Value)
+		return nil
+	})
+	if err := strs.Publish(context.Background(), Ok[string]{Value: "apple"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "apple" {
+		t.Errorf("unexpected deliveries: %v", got)
+	}
+}
+
+// Type parameters of Right are in the different order from Either.
+func TestEither(t *testing.T) {
+	v := NewEitherVisitor(
+		func(e Left[int, string]) string { return "left:" + strconv.Itoa(e.Value) },
+		func(e Right[string, int]) string { return "right:" + e.Value },
+	)
+	match := func(e EitherEnum[int, string]) bool {
+		return MatchEither(e,
+			func(e Left[int, string]) bool { return true },
+			func(e Right[string, int]) bool { return false },
+		)
+	}
+	for _, c := range []struct {
+		e    EitherEnum[int, string]
+		want string
+		left bool
+	}{
+		{Left[int, string]{Value: 1}, "left:1", true},
+		{Right[string, int]{Value: "apple"}, "right:apple", false},
+	} {
+		if got := c.e.Accept(v); got != c.want {
+			t.Errorf("%T: got %q, want %q", c.e, got, c.want)
+		}
+		if got := match(c.e); got != c.left {
+			t.Errorf("%T: matched %t, want %t", c.e, got, c.left)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package jsoncodec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	ShapeVisitor interface {
+		VisitCircle(e Circle)
+		VisitPoint(e Point)
+	}
+	ShapeEnum interface {
+		Accept(v ShapeVisitor)
+	}
+)
+
+func (e Circle) Accept(v ShapeVisitor) {
+	v.VisitCircle(e)
+}
+func (e Point) Accept(v ShapeVisitor) {
+	v.VisitPoint(e)
+}
+
+var _ = []ShapeEnum{Circle{}, Point{}}
+
+type __ShapeJSON struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func MarshalShapeJSON(v Shape) ([]byte, error) {
+	var typ string
+	switch v.(type) {
+	case Circle:
+		typ = "circle"
+	case Point:
+		typ = "point"
+	default:
+		return nil, fmt.Errorf("unexpected Shape: %T", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(__ShapeJSON{Type: typ, Data: data})
+}
+func UnmarshalShapeJSON(data []byte) (Shape, error) {
+	var envelope __ShapeJSON
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	switch envelope.Type {
+	case "circle", "round":
+		var v Circle
+		if len(envelope.Data) > 0 {
+			if err := json.Unmarshal(envelope.Data, &v); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case "point":
+		var v Point
+		if len(envelope.Data) > 0 {
+			if err := json.Unmarshal(envelope.Data, &v); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("unknown type of Shape: %q", envelope.Type)
+}
+
+type (
+	RefVisitor interface {
+		VisitNode(e *Node)
+		VisitLeaf(e *Leaf)
+	}
+	RefEnum interface {
+		Accept(v RefVisitor)
+	}
+)
+
+func (e *Node) Accept(v RefVisitor) {
+	v.VisitNode(e)
+}
+func (e *Leaf) Accept(v RefVisitor) {
+	v.VisitLeaf(e)
+}
+
+var _ = []RefEnum{&Node{}, &Leaf{}}
+
+type __RefJSON struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func MarshalRefJSON(v Ref) ([]byte, error) {
+	var typ string
+	switch v.(type) {
+	case *Node:
+		typ = "Node"
+	case *Leaf:
+		typ = "Leaf"
+	default:
+		return nil, fmt.Errorf("unexpected Ref: %T", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(__RefJSON{Type: typ, Data: data})
+}
+func UnmarshalRefJSON(data []byte) (Ref, error) {
+	var envelope __RefJSON
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	switch envelope.Type {
+	case "Node":
+		var v Node
+		if len(envelope.Data) > 0 {
+			if err := json.Unmarshal(envelope.Data, &v); err != nil {
+				return nil, err
+			}
+		}
+		return &v, nil
+	case "Leaf":
+		var v Leaf
+		if len(envelope.Data) > 0 {
+			if err := json.Unmarshal(envelope.Data, &v); err != nil {
+				return nil, err
+			}
+		}
+		return &v, nil
+	}
+	return nil, fmt.Errorf("unknown type of Ref: %q", envelope.Type)
+}
@@ -0,0 +1,28 @@
+// Package jsoncodec is the fixture of enumgen --json.
+package jsoncodec
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --json="*" --pointer="Ref"
+
+type (
+	Shape  interface{}
+	Circle struct {
+		enum.MemberOf[Shape] `enum:"name=circle,alias=round"`
+		R                    int
+	}
+	Point struct {
+		enum.MemberOf[Shape] `enum:"name=point"`
+	}
+)
+
+type (
+	Ref  interface{}
+	Node struct {
+		enum.MemberOf[Ref]
+		Name string
+	}
+	Leaf struct {
+		enum.MemberOf[Ref]
+	}
+)
@@ -0,0 +1,73 @@
+package jsoncodec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShapeJSON(t *testing.T) {
+	for _, s := range []Shape{Circle{R: 2}, Point{}} {
+		data, err := MarshalShapeJSON(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnmarshalShapeJSON(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, s) {
+			t.Errorf("round trip of %s: got %#v, want %#v", data, got, s)
+		}
+	}
+}
+
+func TestUnmarshalShapeJSON(t *testing.T) {
+	for _, c := range []struct {
+		data string
+		want Shape
+	}{
+		{`{"type":"circle","data":{"R":3}}`, Circle{R: 3}},
+		{`{"type":"round","data":{"R":3}}`, Circle{R: 3}},
+		{`{"type":"point","data":{}}`, Point{}},
+		{`{"type":"point","data":null}`, Point{}},
+		{`{"type":"point"}`, Point{}},
+		{`{"type":"circle"}`, Circle{}},
+	} {
+		got, err := UnmarshalShapeJSON([]byte(c.data))
+		if err != nil {
+			t.Errorf("%s: %s", c.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %#v, want %#v", c.data, got, c.want)
+		}
+	}
+
+	for _, data := range []string{
+		`{"type":"square"}`,
+		`{"type":"circle","data":{"R":"3"}}`,
+		`[]`,
+	} {
+		if _, err := UnmarshalShapeJSON([]byte(data)); err == nil {
+			t.Errorf("%s: error expected", data)
+		}
+	}
+}
+
+func TestUnmarshalRefJSON(t *testing.T) {
+	got, err := UnmarshalRefJSON([]byte(`{"type":"Leaf"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.(*Leaf); !ok {
+		t.Errorf("unexpected type: %T", got)
+	}
+
+	got, err = UnmarshalRefJSON([]byte(`{"type":"Node","data":{"Name":"n"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := got.(*Node); !ok || n.Name != "n" {
+		t.Errorf("unexpected value: %#v", got)
+	}
+}
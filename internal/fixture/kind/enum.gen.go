@@ -0,0 +1,127 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package kind
+
+import "fmt"
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple)
+		VisitBanana(e Banana)
+		VisitGrape(e Grape)
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor)
+		Kind() FruitsKind
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) {
+	v.VisitApple(e)
+}
+func (e Banana) Accept(v FruitsVisitor) {
+	v.VisitBanana(e)
+}
+func (e Grape) Accept(v FruitsVisitor) {
+	v.VisitGrape(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Banana{}, Grape{}}
+
+type FruitsKind int
+
+const (
+	FruitsKindApple FruitsKind = iota + 1
+	FruitsKindBanana
+	FruitsKindGrape
+)
+
+func (Apple) Kind() FruitsKind {
+	return FruitsKindApple
+}
+func (Banana) Kind() FruitsKind {
+	return FruitsKindBanana
+}
+func (Grape) Kind() FruitsKind {
+	return FruitsKindGrape
+}
+func (k FruitsKind) String() string {
+	switch k {
+	case FruitsKindApple:
+		return "apple"
+	case FruitsKindBanana:
+		return "banana"
+	case FruitsKindGrape:
+		return "Grape"
+	}
+	return fmt.Sprintf("FruitsKind(%d)", int(k))
+}
+func ParseFruitsKind(s string) (FruitsKind, error) {
+	switch s {
+	case "apple", "ringo":
+		return FruitsKindApple, nil
+	case "banana":
+		return FruitsKindBanana, nil
+	case "Grape":
+		return FruitsKindGrape, nil
+	}
+	return 0, fmt.Errorf("unknown FruitsKind: %q", s)
+}
+func AllFruitsKinds() []FruitsKind {
+	return []FruitsKind{FruitsKindApple, FruitsKindBanana, FruitsKindGrape}
+}
+
+type (
+	ColorVisitor interface {
+		VisitApple(e Apple)
+		VisitBanana(e Banana)
+	}
+	ColorEnum interface {
+		AcceptColor(v ColorVisitor)
+		ColorKind() ColorKind
+	}
+)
+
+func (e Apple) AcceptColor(v ColorVisitor) {
+	v.VisitApple(e)
+}
+func (e Banana) AcceptColor(v ColorVisitor) {
+	v.VisitBanana(e)
+}
+
+var _ = []ColorEnum{Apple{}, Banana{}}
+
+type ColorKind int
+
+const (
+	ColorKindApple ColorKind = iota + 1
+	ColorKindBanana
+)
+
+func (Apple) ColorKind() ColorKind {
+	return ColorKindApple
+}
+func (Banana) ColorKind() ColorKind {
+	return ColorKindBanana
+}
+func (k ColorKind) String() string {
+	switch k {
+	case ColorKindApple:
+		return "red"
+	case ColorKindBanana:
+		return "yellow"
+	}
+	return fmt.Sprintf("ColorKind(%d)", int(k))
+}
+func ParseColorKind(s string) (ColorKind, error) {
+	switch s {
+	case "red":
+		return ColorKindApple, nil
+	case "yellow":
+		return ColorKindBanana, nil
+	}
+	return 0, fmt.Errorf("unknown ColorKind: %q", s)
+}
+func AllColorKinds() []ColorKind {
+	return []ColorKind{ColorKindApple, ColorKindBanana}
+}
@@ -0,0 +1,23 @@
+// Package kind is the fixture of Kind of the members joining multiple enums.
+package kind
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --kind="Fruits" --kind="Color:*Kind" --accept="Color:AcceptColor"
+
+type (
+	Fruits interface{}
+	Color  interface{}
+
+	Apple struct {
+		_ enum.MemberOf[Fruits] `enum:"name=apple,alias=ringo"`
+		_ enum.MemberOf[Color]  `enum:"name=red"`
+	}
+	Banana struct {
+		_ enum.MemberOf[Fruits] `enum:"name=banana"`
+		_ enum.MemberOf[Color]  `enum:"name=yellow"`
+	}
+	Grape struct {
+		enum.MemberOf[Fruits]
+	}
+)
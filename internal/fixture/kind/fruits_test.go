@@ -0,0 +1,57 @@
+package kind
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	if k := (Apple{}).Kind(); k != FruitsKindApple || k.String() != "apple" {
+		t.Errorf("unexpected kind of Apple in Fruits: %v", k)
+	}
+	if k := (Apple{}).ColorKind(); k != ColorKindApple || k.String() != "red" {
+		t.Errorf("unexpected kind of Apple in Color: %v", k)
+	}
+	if s := FruitsKind(0).String(); s != "FruitsKind(0)" {
+		t.Errorf("unexpected string of invalid kind: %s", s)
+	}
+
+	var (
+		f FruitsEnum = Grape{}
+		c ColorEnum  = Banana{}
+	)
+	if f.Kind() != FruitsKindGrape || f.Kind().String() != "Grape" {
+		t.Errorf("unexpected kind of Grape in Fruits: %v", f.Kind())
+	}
+	if c.ColorKind() != ColorKindBanana {
+		t.Errorf("unexpected kind of Banana in Color: %v", c.ColorKind())
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	for s, want := range map[string]FruitsKind{
+		"apple":  FruitsKindApple,
+		"ringo":  FruitsKindApple,
+		"banana": FruitsKindBanana,
+		"Grape":  FruitsKindGrape,
+	} {
+		if got, err := ParseFruitsKind(s); err != nil || got != want {
+			t.Errorf("ParseFruitsKind(%q): got %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseFruitsKind("Apple"); err == nil {
+		t.Error("type name must not be parsed if the member is named")
+	}
+	if got, err := ParseColorKind("yellow"); err != nil || got != ColorKindBanana {
+		t.Errorf("ParseColorKind: got %v, %v", got, err)
+	}
+}
+
+func TestAllKinds(t *testing.T) {
+	if got, want := AllFruitsKinds(), []FruitsKind{FruitsKindApple, FruitsKindBanana, FruitsKindGrape}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := AllColorKinds(), []ColorKind{ColorKindApple, ColorKindBanana}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,181 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package list
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple)
+		VisitOrange(e Orange)
+		VisitGrape(e Grape)
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor)
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) {
+	v.VisitApple(e)
+}
+func (e Orange) Accept(v FruitsVisitor) {
+	v.VisitOrange(e)
+}
+func (e Grape) Accept(v FruitsVisitor) {
+	v.VisitGrape(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Orange{}, Grape{}}
+
+const FruitsCount = 3
+
+func AllFruits() []Fruits {
+	return []Fruits{Apple{}, Orange{}, Grape{}}
+}
+func FruitsMembers() func(yield func(Fruits) bool) {
+	return func(yield func(Fruits) bool) {
+		for _, m := range AllFruits() {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+type (
+	ShapeVisitor interface {
+		VisitCircle(e *Circle)
+		VisitPoint(e *Point)
+	}
+	ShapeEnum interface {
+		Accept(v ShapeVisitor)
+	}
+)
+
+func (e *Circle) Accept(v ShapeVisitor) {
+	v.VisitCircle(e)
+}
+func (e *Point) Accept(v ShapeVisitor) {
+	v.VisitPoint(e)
+}
+
+var _ = []ShapeEnum{&Circle{}, &Point{}}
+
+const ShapeCount = 2
+
+func AllShape() []Shape {
+	return []Shape{&Circle{}, &Point{}}
+}
+func ShapeMembers() func(yield func(Shape) bool) {
+	return func(yield func(Shape) bool) {
+		for _, m := range AllShape() {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+type (
+	EventVisitor interface {
+		VisitOrderEvent(e OrderEvent)
+		VisitLogin(e Login)
+	}
+	EventEnum interface {
+		Accept(v EventVisitor)
+	}
+)
+
+func (e Placed) Accept(v EventVisitor) {
+	v.VisitOrderEvent(e)
+}
+func (e Cancelled) Accept(v EventVisitor) {
+	v.VisitOrderEvent(e)
+}
+func (e Login) Accept(v EventVisitor) {
+	v.VisitLogin(e)
+}
+
+var _ = []EventEnum{Placed{}, Cancelled{}, Login{}}
+
+const EventCount = 3
+
+func AllEvent() []Event {
+	return []Event{Placed{}, Cancelled{}, Login{}}
+}
+func EventMembers() func(yield func(Event) bool) {
+	return func(yield func(Event) bool) {
+		for _, m := range AllEvent() {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+type (
+	OrderEventVisitor interface {
+		VisitPlaced(e Placed)
+		VisitCancelled(e Cancelled)
+	}
+	OrderEventEnum interface {
+		AcceptOrderEvent(v OrderEventVisitor)
+	}
+)
+
+func (e Placed) AcceptOrderEvent(v OrderEventVisitor) {
+	v.VisitPlaced(e)
+}
+func (e Cancelled) AcceptOrderEvent(v OrderEventVisitor) {
+	v.VisitCancelled(e)
+}
+
+var _ = []OrderEventEnum{Placed{}, Cancelled{}}
+
+const OrderEventCount = 2
+
+func AllOrderEvent() []OrderEvent {
+	return []OrderEvent{Placed{}, Cancelled{}}
+}
+func OrderEventMembers() func(yield func(OrderEvent) bool) {
+	return func(yield func(OrderEvent) bool) {
+		for _, m := range AllOrderEvent() {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+type (
+	ResultVisitor[T any] interface {
+		VisitOk(e Ok[T])
+		VisitErr(e Err[T])
+	}
+	ResultEnum[T any] interface {
+		Accept(v ResultVisitor[T])
+	}
+)
+
+func (e Ok[T]) Accept(v ResultVisitor[T]) {
+	v.VisitOk(e)
+}
+func (e Err[T]) Accept(v ResultVisitor[T]) {
+	v.VisitErr(e)
+}
+func _[T any]() {
+	_ = []ResultEnum[T]{Ok[T]{}, Err[T]{}}
+}
+
+const ResultCount = 2
+
+func AllResult[T any]() []Result[T] {
+	return []Result[T]{Ok[T]{}, Err[T]{}}
+}
+func ResultMembers[T any]() func(yield func(Result[T]) bool) {
+	return func(yield func(Result[T]) bool) {
+		for _, m := range AllResult[T]() {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package list is the fixture of listing and iteration of members.
+package list
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --list="*" --pointer="Shape" --accept="OrderEvent:AcceptOrderEvent"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+	Grape struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+type (
+	Shape  interface{}
+	Circle struct {
+		enum.MemberOf[Shape]
+		R int
+	}
+	Point struct {
+		enum.MemberOf[Shape]
+	}
+)
+
+// Event lists the leaves of the nested enum OrderEvent.
+type (
+	Event      interface{}
+	OrderEvent interface {
+		enum.SubEnumOf[Event]
+	}
+	Placed struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Cancelled struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Login struct {
+		enum.MemberOf[Event]
+	}
+)
+
+type (
+	Result[T any] interface{}
+	Ok[T any]     struct {
+		enum.MemberOf[Result[T]]
+		Value T
+	}
+	Err[T any] struct {
+		enum.MemberOf[Result[T]]
+		Err error
+	}
+)
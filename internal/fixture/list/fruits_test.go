@@ -0,0 +1,75 @@
+package list
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllFruits(t *testing.T) {
+	want := []Fruits{Apple{}, Orange{}, Grape{}}
+	got := AllFruits()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if len(got) != FruitsCount {
+		t.Errorf("FruitsCount is %d, want %d", FruitsCount, len(got))
+	}
+}
+
+func TestFruitsMembers(t *testing.T) {
+	var got []Fruits
+	FruitsMembers()(func(f Fruits) bool {
+		got = append(got, f)
+		return true
+	})
+	if !reflect.DeepEqual(got, AllFruits()) {
+		t.Errorf("got %#v", got)
+	}
+
+	// stop iteration
+	got = nil
+	FruitsMembers()(func(f Fruits) bool {
+		got = append(got, f)
+		return len(got) < 2
+	})
+	if want := []Fruits{Apple{}, Orange{}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+// Members referred by pointer are allocated for each call.
+func TestAllShape(t *testing.T) {
+	a, b := AllShape(), AllShape()
+	if !reflect.DeepEqual(a, []Shape{&Circle{}, &Point{}}) {
+		t.Errorf("got %#v", a)
+	}
+	a[0].(*Circle).R = 1
+	if b[0].(*Circle).R != 0 {
+		t.Error("members are shared between calls")
+	}
+}
+
+func TestAllEvent(t *testing.T) {
+	want := []Event{Placed{}, Cancelled{}, Login{}}
+	if got := AllEvent(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if EventCount != 3 || OrderEventCount != 2 {
+		t.Errorf("unexpected count: EventCount=%d, OrderEventCount=%d", EventCount, OrderEventCount)
+	}
+}
+
+func TestAllResult(t *testing.T) {
+	want := []Result[int]{Ok[int]{}, Err[int]{}}
+	if got := AllResult[int](); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	var n int
+	ResultMembers[string]()(func(r Result[string]) bool {
+		n++
+		return true
+	})
+	if n != ResultCount {
+		t.Errorf("iterated %d members, want %d", n, ResultCount)
+	}
+}
@@ -0,0 +1,172 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package multi
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	EventVisitor interface {
+		VisitPlaced(ctx context.Context, e Placed) error
+		VisitCancelled(ctx context.Context, e Cancelled) error
+	}
+	EventEnum interface {
+		Accept(ctx context.Context, v EventVisitor) error
+	}
+)
+
+func (e Placed) Accept(ctx context.Context, v EventVisitor) error {
+	return v.VisitPlaced(ctx, e)
+}
+func (e Cancelled) Accept(ctx context.Context, v EventVisitor) error {
+	return v.VisitCancelled(ctx, e)
+}
+
+var _ = []EventEnum{Placed{}, Cancelled{}}
+
+type __EventVisitor struct {
+	__VisitPlaced    func(context.Context, Placed) error
+	__VisitCancelled func(context.Context, Cancelled) error
+}
+
+func NewEventVisitor(__VisitPlaced func(ctx context.Context, e Placed) error, __VisitCancelled func(ctx context.Context, e Cancelled) error) EventVisitor {
+	return &__EventVisitor{__VisitPlaced: __VisitPlaced, __VisitCancelled: __VisitCancelled}
+}
+func (v __EventVisitor) VisitPlaced(ctx context.Context, e Placed) error {
+	return v.__VisitPlaced(ctx, e)
+}
+func (v __EventVisitor) VisitCancelled(ctx context.Context, e Cancelled) error {
+	return v.__VisitCancelled(ctx, e)
+}
+func MultiEventVisitor(vs ...EventVisitor) EventVisitor {
+	return __MultiEventVisitor(vs)
+}
+
+type __MultiEventVisitor []EventVisitor
+
+func (m __MultiEventVisitor) VisitPlaced(ctx context.Context, e Placed) error {
+	for _, v := range m {
+		if err := v.VisitPlaced(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (m __MultiEventVisitor) VisitCancelled(ctx context.Context, e Cancelled) error {
+	for _, v := range m {
+		if err := v.VisitCancelled(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type (
+	AuditVisitor interface {
+		VisitLogin(e Login) error
+		VisitLogout(e Logout) error
+	}
+	AuditEnum interface {
+		Accept(v AuditVisitor) error
+	}
+)
+
+func (e Login) Accept(v AuditVisitor) error {
+	return v.VisitLogin(e)
+}
+func (e Logout) Accept(v AuditVisitor) error {
+	return v.VisitLogout(e)
+}
+
+var _ = []AuditEnum{Login{}, Logout{}}
+
+type __AuditVisitor struct {
+	__VisitLogin  func(Login) error
+	__VisitLogout func(Logout) error
+}
+
+func NewAuditVisitor(__VisitLogin func(e Login) error, __VisitLogout func(e Logout) error) AuditVisitor {
+	return &__AuditVisitor{__VisitLogin: __VisitLogin, __VisitLogout: __VisitLogout}
+}
+func (v __AuditVisitor) VisitLogin(e Login) error {
+	return v.__VisitLogin(e)
+}
+func (v __AuditVisitor) VisitLogout(e Logout) error {
+	return v.__VisitLogout(e)
+}
+func JoinAuditVisitor(vs ...AuditVisitor) AuditVisitor {
+	return __JoinAuditVisitor(vs)
+}
+
+type __JoinAuditVisitor []AuditVisitor
+
+func (m __JoinAuditVisitor) VisitLogin(e Login) error {
+	var errs []error
+	for _, v := range m {
+		if err := v.VisitLogin(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+func (m __JoinAuditVisitor) VisitLogout(e Logout) error {
+	var errs []error
+	for _, v := range m {
+		if err := v.VisitLogout(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type (
+	NoticeVisitor interface {
+		VisitInfo(e Info)
+		VisitWarn(e Warn)
+	}
+	NoticeEnum interface {
+		Accept(v NoticeVisitor)
+	}
+)
+
+func (e Info) Accept(v NoticeVisitor) {
+	v.VisitInfo(e)
+}
+func (e Warn) Accept(v NoticeVisitor) {
+	v.VisitWarn(e)
+}
+
+var _ = []NoticeEnum{Info{}, Warn{}}
+
+type __NoticeVisitor struct {
+	__VisitInfo func(Info)
+	__VisitWarn func(Warn)
+}
+
+func NewNoticeVisitor(__VisitInfo func(e Info), __VisitWarn func(e Warn)) NoticeVisitor {
+	return &__NoticeVisitor{__VisitInfo: __VisitInfo, __VisitWarn: __VisitWarn}
+}
+func (v __NoticeVisitor) VisitInfo(e Info) {
+	v.__VisitInfo(e)
+}
+func (v __NoticeVisitor) VisitWarn(e Warn) {
+	v.__VisitWarn(e)
+}
+func MultiNoticeVisitor(vs ...NoticeVisitor) NoticeVisitor {
+	return __MultiNoticeVisitor(vs)
+}
+
+type __MultiNoticeVisitor []NoticeVisitor
+
+func (m __MultiNoticeVisitor) VisitInfo(e Info) {
+	for _, v := range m {
+		v.VisitInfo(e)
+	}
+}
+func (m __MultiNoticeVisitor) VisitWarn(e Warn) {
+	for _, v := range m {
+		v.VisitWarn(e)
+	}
+}
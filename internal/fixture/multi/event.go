@@ -0,0 +1,44 @@
+// Package multi is the fixture of visitors fanning out to multiple visitors.
+package multi
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --multi="Event" --multi="Audit:Join*:join" --multi="Notice" --visitor-impl="*"
+
+// Event stops at the first error.
+type (
+	Event interface {
+		enum.VisitorContext
+		enum.VisitorReturns[error]
+	}
+	Placed struct {
+		enum.MemberOf[Event]
+	}
+	Cancelled struct {
+		enum.MemberOf[Event]
+	}
+)
+
+// Audit aggregates errors of all visitors.
+type (
+	Audit interface {
+		enum.VisitorReturns[error]
+	}
+	Login struct {
+		enum.MemberOf[Audit]
+	}
+	Logout struct {
+		enum.MemberOf[Audit]
+	}
+)
+
+// Notice returns nothing.
+type (
+	Notice interface{}
+	Info   struct {
+		enum.MemberOf[Notice]
+	}
+	Warn struct {
+		enum.MemberOf[Notice]
+	}
+)
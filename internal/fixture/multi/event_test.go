@@ -0,0 +1,98 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	errFirst  = errors.New("first")
+	errSecond = errors.New("second")
+)
+
+type ctxKey struct{}
+
+func TestMultiEventVisitor(t *testing.T) {
+	var calls []string
+	visitor := func(name string, err error) EventVisitor {
+		return NewEventVisitor(
+			func(ctx context.Context, e Placed) error {
+				if ctx.Value(ctxKey{}) != "value" {
+					t.Error("context is not passed")
+				}
+				calls = append(calls, name)
+				return err
+			},
+			func(ctx context.Context, e Cancelled) error {
+				calls = append(calls, name)
+				return nil
+			},
+		)
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	v := MultiEventVisitor(visitor("projection", nil), visitor("notification", errFirst), visitor("audit", errSecond))
+	if err := (Placed{}).Accept(ctx, v); err != errFirst {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if want := []string{"projection", "notification"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("visited %v, want %v", calls, want)
+	}
+
+	calls = nil
+	if err := (Cancelled{}).Accept(ctx, v); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if want := []string{"projection", "notification", "audit"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("visited %v, want %v", calls, want)
+	}
+
+	if err := (Placed{}).Accept(ctx, MultiEventVisitor()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestJoinAuditVisitor(t *testing.T) {
+	var n int
+	visitor := func(err error) AuditVisitor {
+		return NewAuditVisitor(
+			func(e Login) error {
+				n++
+				return err
+			},
+			func(e Logout) error {
+				n++
+				return nil
+			},
+		)
+	}
+
+	v := JoinAuditVisitor(visitor(errFirst), visitor(nil), visitor(errSecond))
+	err := (Login{}).Accept(v)
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("errors are not joined: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("visited %d visitors, want 3", n)
+	}
+	if err := (Logout{}).Accept(v); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMultiNoticeVisitor(t *testing.T) {
+	var infos, warns int
+	visitor := NewNoticeVisitor(
+		func(e Info) { infos++ },
+		func(e Warn) { warns++ },
+	)
+	v := MultiNoticeVisitor(visitor, visitor)
+	Info{}.Accept(v)
+	Warn{}.Accept(v)
+	Warn{}.Accept(v)
+	if infos != 2 || warns != 4 {
+		t.Errorf("unexpected visits: info=%d, warn=%d", infos, warns)
+	}
+}
@@ -0,0 +1,120 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package nested
+
+import "fmt"
+
+type (
+	EventVisitor interface {
+		VisitOrderEvent(e OrderEvent)
+		VisitLogin(e Login)
+	}
+	EventEnum interface {
+		Accept(v EventVisitor)
+	}
+)
+
+func (e Placed) Accept(v EventVisitor) {
+	v.VisitOrderEvent(e)
+}
+func (e Cancelled) Accept(v EventVisitor) {
+	v.VisitOrderEvent(e)
+}
+func (e Login) Accept(v EventVisitor) {
+	v.VisitLogin(e)
+}
+
+var _ = []EventEnum{Placed{}, Cancelled{}, Login{}}
+
+func MatchEvent[R any](e EventEnum, visitOrderEvent func(e OrderEvent) R, visitLogin func(e Login) R) R {
+	switch e := e.(type) {
+	case Placed:
+		return visitOrderEvent(e)
+	case Cancelled:
+		return visitOrderEvent(e)
+	case Login:
+		return visitLogin(e)
+	}
+	panic(fmt.Sprintf("unexpected EventEnum: %T", e))
+}
+
+type (
+	OrderEventVisitor interface {
+		VisitPlaced(e Placed)
+		VisitCancelled(e Cancelled)
+	}
+	OrderEventEnum interface {
+		AcceptOrderEvent(v OrderEventVisitor)
+	}
+)
+
+func (e Placed) AcceptOrderEvent(v OrderEventVisitor) {
+	v.VisitPlaced(e)
+}
+func (e Cancelled) AcceptOrderEvent(v OrderEventVisitor) {
+	v.VisitCancelled(e)
+}
+
+var _ = []OrderEventEnum{Placed{}, Cancelled{}}
+
+func MatchOrderEvent[R any](e OrderEventEnum, visitPlaced func(e Placed) R, visitCancelled func(e Cancelled) R) R {
+	switch e := e.(type) {
+	case Placed:
+		return visitPlaced(e)
+	case Cancelled:
+		return visitCancelled(e)
+	}
+	panic(fmt.Sprintf("unexpected OrderEventEnum: %T", e))
+}
+
+type (
+	NoticeVisitor interface {
+		VisitWelcome(e Welcome)
+		VisitPush(e Push)
+	}
+	NoticeEnum interface {
+		Accept(v NoticeVisitor)
+	}
+)
+
+func (e Welcome) Accept(v NoticeVisitor) {
+	v.VisitWelcome(e)
+}
+func (e Push) Accept(v NoticeVisitor) {
+	v.VisitPush(e)
+}
+
+var _ = []NoticeEnum{Welcome{}, Push{}}
+
+func MatchNotice[R any](e NoticeEnum, visitWelcome func(e Welcome) R, visitPush func(e Push) R) R {
+	switch e := e.(type) {
+	case Welcome:
+		return visitWelcome(e)
+	case Push:
+		return visitPush(e)
+	}
+	panic(fmt.Sprintf("unexpected NoticeEnum: %T", e))
+}
+
+type (
+	MailVisitor interface {
+		VisitWelcome(e Welcome)
+	}
+	MailEnum interface {
+		AcceptMail(v MailVisitor)
+	}
+)
+
+func (e Welcome) AcceptMail(v MailVisitor) {
+	v.VisitWelcome(e)
+}
+
+var _ = []MailEnum{Welcome{}}
+
+func MatchMail[R any](e MailEnum, visitWelcome func(e Welcome) R) R {
+	switch e := e.(type) {
+	case Welcome:
+		return visitWelcome(e)
+	}
+	panic(fmt.Sprintf("unexpected MailEnum: %T", e))
+}
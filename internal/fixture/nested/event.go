@@ -0,0 +1,35 @@
+// Package nested is the fixture of nested enums.
+package nested
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --match="*" --accept="OrderEvent:AcceptOrderEvent" --accept="Mail:AcceptMail" --flatten="Notice"
+
+type (
+	Event      interface{}
+	OrderEvent interface {
+		enum.SubEnumOf[Event]
+	}
+	Placed struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Cancelled struct {
+		enum.MemberOf[OrderEvent]
+	}
+	Login struct {
+		enum.MemberOf[Event]
+	}
+)
+
+type (
+	Notice interface{}
+	Mail   interface {
+		enum.SubEnumOf[Notice]
+	}
+	Welcome struct {
+		enum.MemberOf[Mail]
+	}
+	Push struct {
+		enum.MemberOf[Notice]
+	}
+)
@@ -0,0 +1,69 @@
+package nested
+
+import "testing"
+
+func TestMatchEvent(t *testing.T) {
+	match := func(e EventEnum) string {
+		return MatchEvent(e,
+			func(e OrderEvent) string {
+				switch e.(type) {
+				case Placed:
+					return "order:placed"
+				case Cancelled:
+					return "order:cancelled"
+				}
+				return "order:unknown"
+			},
+			func(e Login) string { return "login" },
+		)
+	}
+	for _, c := range []struct {
+		e    EventEnum
+		want string
+	}{
+		{Placed{}, "order:placed"},
+		{Cancelled{}, "order:cancelled"},
+		{Login{}, "login"},
+	} {
+		if got := match(c.e); got != c.want {
+			t.Errorf("%T: got %q, want %q", c.e, got, c.want)
+		}
+	}
+}
+
+func TestMatchNoticeFlattened(t *testing.T) {
+	match := func(e NoticeEnum) string {
+		return MatchNotice(e,
+			func(e Welcome) string { return "welcome" },
+			func(e Push) string { return "push" },
+		)
+	}
+	if got := match(Welcome{}); got != "welcome" {
+		t.Errorf("Welcome: got %q", got)
+	}
+	if got := match(Push{}); got != "push" {
+		t.Errorf("Push: got %q", got)
+	}
+}
+
+// Match function must agree with the visitor.
+func TestMatchEventAgreesWithVisitor(t *testing.T) {
+	for _, e := range []EventEnum{Placed{}, Cancelled{}, Login{}} {
+		v := &recorder{}
+		e.Accept(v)
+		got := MatchEvent(e,
+			func(e OrderEvent) string { return "OrderEvent" },
+			func(e Login) string { return "Login" },
+		)
+		if got != v.visited {
+			t.Errorf("%T: match calls %s, visitor visits %s", e, got, v.visited)
+		}
+	}
+}
+
+type recorder struct {
+	visited string
+}
+
+func (r *recorder) VisitOrderEvent(e OrderEvent) { r.visited = "OrderEvent" }
+func (r *recorder) VisitLogin(e Login)           { r.visited = "Login" }
@@ -0,0 +1,41 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package pointer
+
+type (
+	ImageVisitor interface {
+		VisitRaw(e Raw)
+		VisitEmpty(e Empty)
+	}
+	ImageEnum interface {
+		Accept(v ImageVisitor)
+	}
+)
+
+func (e Raw) Accept(v ImageVisitor) {
+	v.VisitRaw(e)
+}
+func (e Empty) Accept(v ImageVisitor) {
+	v.VisitEmpty(e)
+}
+
+var _ = []ImageEnum{Raw{}, Empty{}}
+
+type (
+	ImageRefVisitor interface {
+		VisitRawRef(e *RawRef)
+		VisitEmptyRef(e *EmptyRef)
+	}
+	ImageRefEnum interface {
+		Accept(v ImageRefVisitor)
+	}
+)
+
+func (e *RawRef) Accept(v ImageRefVisitor) {
+	v.VisitRawRef(e)
+}
+func (e *EmptyRef) Accept(v ImageRefVisitor) {
+	v.VisitEmptyRef(e)
+}
+
+var _ = []ImageRefEnum{&RawRef{}, &EmptyRef{}}
@@ -0,0 +1,28 @@
+// Package pointer is the fixture of enums referring large members by value and by pointer.
+package pointer
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --pointer="ImageRef"
+
+type (
+	Image interface{}
+	Raw   struct {
+		enum.MemberOf[Image]
+		Pixels [4096]byte
+	}
+	Empty struct {
+		enum.MemberOf[Image]
+	}
+)
+
+type (
+	ImageRef interface{}
+	RawRef   struct {
+		enum.MemberOf[ImageRef]
+		Pixels [4096]byte
+	}
+	EmptyRef struct {
+		enum.MemberOf[ImageRef]
+	}
+)
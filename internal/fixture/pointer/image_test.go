@@ -0,0 +1,61 @@
+package pointer
+
+import "testing"
+
+type sum struct {
+	n int
+}
+
+func (s *sum) VisitRaw(e Raw) {
+	s.n += int(e.Pixels[0])
+}
+
+func (s *sum) VisitEmpty(e Empty) {}
+
+func (s *sum) VisitRawRef(e *RawRef) {
+	s.n += int(e.Pixels[0])
+}
+
+func (s *sum) VisitEmptyRef(e *EmptyRef) {}
+
+type fill struct{}
+
+func (fill) VisitRawRef(e *RawRef) {
+	e.Pixels[0] = 1
+}
+
+func (fill) VisitEmptyRef(e *EmptyRef) {}
+
+func TestAcceptPointer(t *testing.T) {
+	raw := &RawRef{}
+	var e ImageRefEnum = raw
+	e.Accept(fill{})
+	if raw.Pixels[0] != 1 {
+		t.Error("visitor did not mutate the member referred by pointer")
+	}
+}
+
+// BenchmarkAccept compares the dispatch on the large member referred by value(copied on each call)
+// and by pointer(--pointer).
+func BenchmarkAccept(b *testing.B) {
+	b.Run("value", func(b *testing.B) {
+		var (
+			e ImageEnum = Raw{}
+			v sum
+		)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			e.Accept(&v)
+		}
+	})
+	b.Run("pointer", func(b *testing.B) {
+		var (
+			e ImageRefEnum = &RawRef{}
+			v sum
+		)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			e.Accept(&v)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package unimplemented
+
+import (
+	"fmt"
+
+	enum "github.com/daichitakahashi/go-enum"
+)
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple)
+		VisitOrange(e Orange)
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor)
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) {
+	v.VisitApple(e)
+}
+func (e Orange) Accept(v FruitsVisitor) {
+	v.VisitOrange(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Orange{}}
+
+type (
+	UnimplementedFruitsVisitor struct{}
+	NopFruitsVisitor           struct{}
+)
+
+func (UnimplementedFruitsVisitor) VisitApple(Apple) {
+	panic(fmt.Errorf("FruitsVisitor.VisitApple: %w", enum.ErrUnimplemented))
+}
+func (UnimplementedFruitsVisitor) VisitOrange(Orange) {
+	panic(fmt.Errorf("FruitsVisitor.VisitOrange: %w", enum.ErrUnimplemented))
+}
+func (NopFruitsVisitor) VisitApple(Apple) {
+}
+func (NopFruitsVisitor) VisitOrange(Orange) {
+}
+
+type (
+	EventVisitor interface {
+		VisitCreated(e Created) error
+		VisitDeleted(e Deleted) error
+	}
+	EventEnum interface {
+		Accept(v EventVisitor) error
+	}
+)
+
+func (e Created) Accept(v EventVisitor) error {
+	return v.VisitCreated(e)
+}
+func (e Deleted) Accept(v EventVisitor) error {
+	return v.VisitDeleted(e)
+}
+
+var _ = []EventEnum{Created{}, Deleted{}}
+
+type (
+	UnimplementedEventVisitor struct{}
+	NopEventVisitor           struct{}
+)
+
+func (UnimplementedEventVisitor) VisitCreated(Created) error {
+	return fmt.Errorf("EventVisitor.VisitCreated: %w", enum.ErrUnimplemented)
+}
+func (UnimplementedEventVisitor) VisitDeleted(Deleted) error {
+	return fmt.Errorf("EventVisitor.VisitDeleted: %w", enum.ErrUnimplemented)
+}
+func (NopEventVisitor) VisitCreated(Created) error {
+	return nil
+}
+func (NopEventVisitor) VisitDeleted(Deleted) error {
+	return nil
+}
+
+type (
+	TaskVisitor interface {
+		VisitBuild(e *Build) (int, error)
+		VisitDeploy(e *Deploy) (int, error)
+	}
+	TaskEnum interface {
+		Accept(v TaskVisitor) (int, error)
+	}
+)
+
+func (e *Build) Accept(v TaskVisitor) (int, error) {
+	return v.VisitBuild(e)
+}
+func (e *Deploy) Accept(v TaskVisitor) (int, error) {
+	return v.VisitDeploy(e)
+}
+
+var _ = []TaskEnum{&Build{}, &Deploy{}}
+
+type (
+	UnimplementedTaskVisitor struct{}
+	NopTaskVisitor           struct{}
+)
+
+func (UnimplementedTaskVisitor) VisitBuild(*Build) (int, error) {
+	var r0 int
+	return r0, fmt.Errorf("TaskVisitor.VisitBuild: %w", enum.ErrUnimplemented)
+}
+func (UnimplementedTaskVisitor) VisitDeploy(*Deploy) (int, error) {
+	var r0 int
+	return r0, fmt.Errorf("TaskVisitor.VisitDeploy: %w", enum.ErrUnimplemented)
+}
+func (NopTaskVisitor) VisitBuild(*Build) (int, error) {
+	var r0 int
+	return r0, nil
+}
+func (NopTaskVisitor) VisitDeploy(*Deploy) (int, error) {
+	var r0 int
+	return r0, nil
+}
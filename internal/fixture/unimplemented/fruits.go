@@ -0,0 +1,40 @@
+// Package unimplemented is the fixture of unimplemented and no-op visitors embedded in the visitor handling some members.
+package unimplemented
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --unimplemented="*" --pointer="Task"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+type (
+	Event interface {
+		enum.VisitorReturns[error]
+	}
+	Created struct {
+		enum.MemberOf[Event]
+	}
+	Deleted struct {
+		enum.MemberOf[Event]
+	}
+)
+
+type (
+	Task interface {
+		enum.VisitorReturns2[int, error]
+	}
+	Build struct {
+		enum.MemberOf[Task]
+	}
+	Deploy struct {
+		enum.MemberOf[Task]
+	}
+)
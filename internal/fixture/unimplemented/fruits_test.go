@@ -0,0 +1,96 @@
+package unimplemented
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+// The visitors handle only the first member, and the rest is handled by the embedded visitor.
+type (
+	fruitsVisitor struct {
+		UnimplementedFruitsVisitor
+		visited bool
+	}
+	eventVisitor struct {
+		UnimplementedEventVisitor
+	}
+	nopEventVisitor struct {
+		NopEventVisitor
+	}
+	taskVisitor struct {
+		NopTaskVisitor
+	}
+)
+
+func (v *fruitsVisitor) VisitApple(e Apple) {
+	v.visited = true
+}
+
+func (eventVisitor) VisitCreated(e Created) error {
+	return nil
+}
+
+func (nopEventVisitor) VisitCreated(e Created) error {
+	return errors.New("created")
+}
+
+func (taskVisitor) VisitBuild(e *Build) (int, error) {
+	return 1, nil
+}
+
+func TestUnimplementedFruitsVisitor(t *testing.T) {
+	v := &fruitsVisitor{}
+	Apple{}.Accept(v)
+	if !v.visited {
+		t.Error("Apple is not visited")
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, enum.ErrUnimplemented) || err.Error() != "FruitsVisitor.VisitOrange: "+enum.ErrUnimplemented.Error() {
+			t.Errorf("unexpected panic: %v", err)
+		}
+	}()
+	Orange{}.Accept(v)
+	t.Error("Orange must panic")
+}
+
+func TestUnimplementedEventVisitor(t *testing.T) {
+	if err := (Created{}).Accept(eventVisitor{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (Deleted{}).Accept(eventVisitor{}); !errors.Is(err, enum.ErrUnimplemented) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNopEventVisitor(t *testing.T) {
+	if err := (Created{}).Accept(nopEventVisitor{}); err == nil || err.Error() != "created" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (Deleted{}).Accept(nopEventVisitor{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNopTaskVisitor(t *testing.T) {
+	for _, c := range []struct {
+		e    TaskEnum
+		want int
+	}{
+		{&Build{}, 1},
+		{&Deploy{}, 0},
+	} {
+		got, err := c.e.Accept(taskVisitor{})
+		if got != c.want || err != nil {
+			t.Errorf("%T: unexpected result: %d, %v", c.e, got, err)
+		}
+	}
+
+	n, err := (&Deploy{}).Accept(UnimplementedTaskVisitor{})
+	if n != 0 || !errors.Is(err, enum.ErrUnimplemented) {
+		t.Errorf("unexpected result: %d, %v", n, err)
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package union
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple)
+		VisitOrange(e Orange)
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor)
+	}
+	FruitsMember interface {
+		Apple | Orange
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) {
+	v.VisitApple(e)
+}
+func (e Orange) Accept(v FruitsVisitor) {
+	v.VisitOrange(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Orange{}}
+
+func IsFruits[M FruitsMember](v Fruits) bool {
+	_, ok := v.(M)
+	return ok
+}
+func AsFruits[M FruitsMember](v Fruits) (M, bool) {
+	m, ok := v.(M)
+	return m, ok
+}
+
+type (
+	ShapeVisitor interface {
+		VisitCircle(e *Circle)
+		VisitPoint(e *Point)
+	}
+	ShapeEnum interface {
+		Accept(v ShapeVisitor)
+	}
+	ShapeMember interface {
+		*Circle | *Point
+	}
+)
+
+func (e *Circle) Accept(v ShapeVisitor) {
+	v.VisitCircle(e)
+}
+func (e *Point) Accept(v ShapeVisitor) {
+	v.VisitPoint(e)
+}
+
+var _ = []ShapeEnum{&Circle{}, &Point{}}
+
+func IsShape[M ShapeMember](v Shape) bool {
+	_, ok := v.(M)
+	return ok
+}
+func AsShape[M ShapeMember](v Shape) (M, bool) {
+	m, ok := v.(M)
+	return m, ok
+}
+
+type (
+	ResultVisitor[T any] interface {
+		VisitOk(e Ok[T])
+		VisitErr(e Err[T])
+	}
+	ResultEnum[T any] interface {
+		Accept(v ResultVisitor[T])
+	}
+	ResultMember[T any] interface {
+		Ok[T] | Err[T]
+	}
+)
+
+func (e Ok[T]) Accept(v ResultVisitor[T]) {
+	v.VisitOk(e)
+}
+func (e Err[T]) Accept(v ResultVisitor[T]) {
+	v.VisitErr(e)
+}
+func _[T any]() {
+	_ = []ResultEnum[T]{Ok[T]{}, Err[T]{}}
+}
+func IsResult[M ResultMember[T], T any](v Result[T]) bool {
+	_, ok := v.(M)
+	return ok
+}
+func AsResult[M ResultMember[T], T any](v Result[T]) (M, bool) {
+	m, ok := v.(M)
+	return m, ok
+}
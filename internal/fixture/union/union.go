@@ -0,0 +1,40 @@
+// Package union is the fixture of enumgen --union.
+package union
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --union="*" --pointer="Shape"
+
+type (
+	Fruits interface{}
+	Apple  struct {
+		enum.MemberOf[Fruits]
+		Variety string
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+type (
+	Shape  interface{}
+	Circle struct {
+		enum.MemberOf[Shape]
+		R int
+	}
+	Point struct {
+		enum.MemberOf[Shape]
+	}
+)
+
+type (
+	Result[T any] interface{}
+	Ok[T any]     struct {
+		enum.MemberOf[Result[T]]
+		Value T
+	}
+	Err[T any] struct {
+		enum.MemberOf[Result[T]]
+		Err error
+	}
+)
@@ -0,0 +1,124 @@
+package union
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// describe is the generic code constrained to exactly the members of Fruits.
+func describe[M FruitsMember](f Fruits) string {
+	if _, ok := AsFruits[M](f); ok {
+		return "matched"
+	}
+	return "unmatched"
+}
+
+func TestIsFruits(t *testing.T) {
+	var f Fruits = Apple{Variety: "Fuji"}
+	if !IsFruits[Apple](f) {
+		t.Error("Apple is not narrowed")
+	}
+	if IsFruits[Orange](f) {
+		t.Error("Apple is narrowed to Orange")
+	}
+	if IsFruits[Apple](nil) {
+		t.Error("nil is narrowed")
+	}
+}
+
+func TestAsFruits(t *testing.T) {
+	var f Fruits = Apple{Variety: "Fuji"}
+	if apple, ok := AsFruits[Apple](f); !ok || apple.Variety != "Fuji" {
+		t.Errorf("unexpected result: %#v, %t", apple, ok)
+	}
+	if _, ok := AsFruits[Orange](f); ok {
+		t.Error("Apple is narrowed to Orange")
+	}
+	if got := describe[Apple](f); got != "matched" {
+		t.Errorf("got %q", got)
+	}
+	if got := describe[Orange](f); got != "unmatched" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestAsShape(t *testing.T) {
+	var s Shape = &Circle{R: 2}
+	circle, ok := AsShape[*Circle](s)
+	if !ok || circle.R != 2 {
+		t.Errorf("unexpected result: %#v, %t", circle, ok)
+	}
+	circle.R = 3
+	if s.(*Circle).R != 3 {
+		t.Error("member is copied")
+	}
+	if IsShape[*Point](s) {
+		t.Error("Circle is narrowed to Point")
+	}
+}
+
+func TestAsResult(t *testing.T) {
+	var r Result[int] = Ok[int]{Value: 1}
+	if ok, found := AsResult[Ok[int], int](r); !found || ok.Value != 1 {
+		t.Errorf("unexpected result: %#v, %t", ok, found)
+	}
+	r = Err[int]{Err: errors.New("failed")}
+	if IsResult[Ok[int], int](r) || !IsResult[Err[int], int](r) {
+		t.Error("Err is not narrowed")
+	}
+}
+
+// Narrowing to the type which is not the member does not compile.
+func TestNarrowNonMember(t *testing.T) {
+	for name, c := range map[string]struct {
+		src string
+		msg string
+	}{
+		"string": {
+			src: "var _ = IsFruits[string](Apple{})",
+			msg: "string does not satisfy FruitsMember",
+		},
+		"member of other enum": {
+			src: "var _, _ = AsFruits[Circle](Apple{})",
+			msg: "Circle does not satisfy FruitsMember",
+		},
+		"pointer to member": {
+			src: "var _ = IsFruits[*Apple](Apple{})",
+			msg: "*Apple does not satisfy FruitsMember",
+		},
+		"type argument of other instance": {
+			src: "var _ = IsResult[Ok[string], int](nil)",
+			msg: "Ok[string] does not satisfy ResultMember[int]",
+		},
+	} {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkgs, err := packages.Load(&packages.Config{
+				Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+				Dir:  wd,
+				Overlay: map[string][]byte{
+					filepath.Join(wd, "misuse.go"): []byte("package union\n\n" + c.src + "\n"),
+				},
+			}, ".")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var msgs []string
+			for _, err := range pkgs[0].TypeErrors {
+				msgs = append(msgs, err.Msg)
+			}
+			if !strings.Contains(strings.Join(msgs, "\n"), c.msg) {
+				t.Errorf("unexpected errors: %v", msgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package visitorargs
+
+import (
+	"context"
+	"strings"
+)
+
+type (
+	Op1Visitor interface {
+		VisitWrite1(e Write1, arg0 *strings.Builder)
+	}
+	Op1Enum interface {
+		Accept(v Op1Visitor, arg0 *strings.Builder)
+	}
+)
+
+func (e Write1) Accept(v Op1Visitor, arg0 *strings.Builder) {
+	v.VisitWrite1(e, arg0)
+}
+
+var _ = []Op1Enum{Write1{}}
+
+type __Op1Visitor struct {
+	__VisitWrite1 func(Write1, *strings.Builder)
+}
+
+func NewOp1Visitor(__VisitWrite1 func(e Write1, arg0 *strings.Builder)) Op1Visitor {
+	return &__Op1Visitor{__VisitWrite1: __VisitWrite1}
+}
+func (v __Op1Visitor) VisitWrite1(e Write1, arg0 *strings.Builder) {
+	v.__VisitWrite1(e, arg0)
+}
+
+type (
+	Op2Visitor interface {
+		VisitWrite2(e Write2, arg0 *strings.Builder, arg1 string)
+	}
+	Op2Enum interface {
+		Accept(v Op2Visitor, arg0 *strings.Builder, arg1 string)
+	}
+)
+
+func (e Write2) Accept(v Op2Visitor, arg0 *strings.Builder, arg1 string) {
+	v.VisitWrite2(e, arg0, arg1)
+}
+
+var _ = []Op2Enum{Write2{}}
+
+type __Op2Visitor struct {
+	__VisitWrite2 func(Write2, *strings.Builder, string)
+}
+
+func NewOp2Visitor(__VisitWrite2 func(e Write2, arg0 *strings.Builder, arg1 string)) Op2Visitor {
+	return &__Op2Visitor{__VisitWrite2: __VisitWrite2}
+}
+func (v __Op2Visitor) VisitWrite2(e Write2, arg0 *strings.Builder, arg1 string) {
+	v.__VisitWrite2(e, arg0, arg1)
+}
+
+type (
+	Op3Visitor interface {
+		VisitWrite3(ctx context.Context, e Write3, arg0 *strings.Builder, arg1 string, arg2 int) int
+	}
+	Op3Enum interface {
+		Accept(ctx context.Context, v Op3Visitor, arg0 *strings.Builder, arg1 string, arg2 int) int
+	}
+)
+
+func (e Write3) Accept(ctx context.Context, v Op3Visitor, arg0 *strings.Builder, arg1 string, arg2 int) int {
+	return v.VisitWrite3(ctx, e, arg0, arg1, arg2)
+}
+
+var _ = []Op3Enum{Write3{}}
+
+type __Op3Visitor struct {
+	__VisitWrite3 func(context.Context, Write3, *strings.Builder, string, int) int
+}
+
+func NewOp3Visitor(__VisitWrite3 func(ctx context.Context, e Write3, arg0 *strings.Builder, arg1 string, arg2 int) int) Op3Visitor {
+	return &__Op3Visitor{__VisitWrite3: __VisitWrite3}
+}
+func (v __Op3Visitor) VisitWrite3(ctx context.Context, e Write3, arg0 *strings.Builder, arg1 string, arg2 int) int {
+	return v.__VisitWrite3(ctx, e, arg0, arg1, arg2)
+}
@@ -0,0 +1,39 @@
+// Package visitorargs is the fixture of visitor methods taking additional parameters.
+package visitorargs
+
+import (
+	"strings"
+
+	"github.com/daichitakahashi/go-enum"
+)
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --visitor-impl="*"
+
+type (
+	Op1 interface {
+		enum.VisitorArgs[*strings.Builder]
+	}
+	Write1 struct {
+		enum.MemberOf[Op1]
+	}
+)
+
+type (
+	Op2 interface {
+		enum.VisitorArgs2[*strings.Builder, string]
+	}
+	Write2 struct {
+		enum.MemberOf[Op2]
+	}
+)
+
+type (
+	Op3 interface {
+		enum.VisitorContext
+		enum.VisitorArgs3[*strings.Builder, string, int]
+		enum.VisitorReturns[int]
+	}
+	Write3 struct {
+		enum.MemberOf[Op3]
+	}
+)
@@ -0,0 +1,34 @@
+package visitorargs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAcceptArgs(t *testing.T) {
+	var b strings.Builder
+
+	(Write1{}).Accept(NewOp1Visitor(func(e Write1, w *strings.Builder) {
+		w.WriteString("1;")
+	}), &b)
+
+	(Write2{}).Accept(NewOp2Visitor(func(e Write2, w *strings.Builder, s string) {
+		w.WriteString(s + ";")
+	}), &b, "2")
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "ctx")
+	n := (Write3{}).Accept(ctx, NewOp3Visitor(func(ctx context.Context, e Write3, w *strings.Builder, s string, n int) int {
+		w.WriteString(ctx.Value(ctxKey{}).(string) + "," + s + "," + strconv.Itoa(n) + ";")
+		return n * 2
+	}), &b, "3", 3)
+
+	if got, want := b.String(), "1;2;ctx,3,3;"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if n != 6 {
+		t.Errorf("unexpected result: %d", n)
+	}
+}
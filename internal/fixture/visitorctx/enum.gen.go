@@ -0,0 +1,65 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package visitorctx
+
+import "context"
+
+type (
+	RequestVisitor interface {
+		VisitGet(ctx context.Context, e Get) error
+		VisitDelete(ctx context.Context, e Delete) error
+	}
+	RequestEnum interface {
+		Accept(ctx context.Context, v RequestVisitor) error
+	}
+)
+
+func (e Get) Accept(ctx context.Context, v RequestVisitor) error {
+	return v.VisitGet(ctx, e)
+}
+func (e Delete) Accept(ctx context.Context, v RequestVisitor) error {
+	return v.VisitDelete(ctx, e)
+}
+
+var _ = []RequestEnum{Get{}, Delete{}}
+
+type __RequestVisitor struct {
+	__VisitGet    func(context.Context, Get) error
+	__VisitDelete func(context.Context, Delete) error
+}
+
+func NewRequestVisitor(__VisitGet func(ctx context.Context, e Get) error, __VisitDelete func(ctx context.Context, e Delete) error) RequestVisitor {
+	return &__RequestVisitor{__VisitGet: __VisitGet, __VisitDelete: __VisitDelete}
+}
+func (v __RequestVisitor) VisitGet(ctx context.Context, e Get) error {
+	return v.__VisitGet(ctx, e)
+}
+func (v __RequestVisitor) VisitDelete(ctx context.Context, e Delete) error {
+	return v.__VisitDelete(ctx, e)
+}
+
+type (
+	PingVisitor interface {
+		VisitEcho(ctx context.Context, e Echo)
+	}
+	PingEnum interface {
+		Accept(ctx context.Context, v PingVisitor)
+	}
+)
+
+func (e Echo) Accept(ctx context.Context, v PingVisitor) {
+	v.VisitEcho(ctx, e)
+}
+
+var _ = []PingEnum{Echo{}}
+
+type __PingVisitor struct {
+	__VisitEcho func(context.Context, Echo)
+}
+
+func NewPingVisitor(__VisitEcho func(ctx context.Context, e Echo)) PingVisitor {
+	return &__PingVisitor{__VisitEcho: __VisitEcho}
+}
+func (v __PingVisitor) VisitEcho(ctx context.Context, e Echo) {
+	v.__VisitEcho(ctx, e)
+}
@@ -0,0 +1,31 @@
+// Package visitorctx is the fixture of visitor methods taking context.
+package visitorctx
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --visitor-impl="*"
+
+type (
+	Request interface {
+		enum.VisitorContext
+		enum.VisitorReturns[error]
+	}
+	Get struct {
+		enum.MemberOf[Request]
+		Path string
+	}
+	Delete struct {
+		enum.MemberOf[Request]
+		Path string
+	}
+)
+
+// Ping takes context without return values.
+type (
+	Ping interface {
+		enum.VisitorContext
+	}
+	Echo struct {
+		enum.MemberOf[Ping]
+	}
+)
@@ -0,0 +1,48 @@
+package visitorctx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func TestAcceptContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	errDelete := errors.New("delete")
+
+	var got []string
+	v := NewRequestVisitor(
+		func(ctx context.Context, e Get) error {
+			got = append(got, "get "+e.Path+" "+ctx.Value(ctxKey{}).(string))
+			return nil
+		},
+		func(ctx context.Context, e Delete) error {
+			got = append(got, "delete "+e.Path+" "+ctx.Value(ctxKey{}).(string))
+			return errDelete
+		},
+	)
+	if err := (Get{Path: "/a"}).Accept(ctx, v); err != nil {
+		t.Fatal(err)
+	}
+	if err := (Delete{Path: "/b"}).Accept(ctx, v); !errors.Is(err, errDelete) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "get /a value" || got[1] != "delete /b value" {
+		t.Errorf("unexpected visits: %q", got)
+	}
+}
+
+func TestAcceptContextWithoutReturns(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	var got any
+	var e PingEnum = Echo{}
+	e.Accept(ctx, NewPingVisitor(func(ctx context.Context, e Echo) {
+		got = ctx.Value(ctxKey{})
+	}))
+	if got != "value" {
+		t.Errorf("visitor received unexpected context value: %v", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package visitorfuncs
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	FruitsVisitor interface {
+		VisitApple(e Apple) string
+		VisitOrange(e Orange) string
+		VisitGrape(e Grape) string
+	}
+	FruitsEnum interface {
+		Accept(v FruitsVisitor) string
+	}
+)
+
+func (e Apple) Accept(v FruitsVisitor) string {
+	return v.VisitApple(e)
+}
+func (e Orange) Accept(v FruitsVisitor) string {
+	return v.VisitOrange(e)
+}
+func (e Grape) Accept(v FruitsVisitor) string {
+	return v.VisitGrape(e)
+}
+
+var _ = []FruitsEnum{Apple{}, Orange{}, Grape{}}
+
+type (
+	FruitsVisitorFuncs struct {
+		OnApple  func(e Apple) string
+		OnOrange func(e Orange) string
+		OnGrape  func(e Grape) string
+		Default  func(e FruitsEnum) string
+	}
+	__FruitsVisitorFuncs FruitsVisitorFuncs
+)
+
+func (f FruitsVisitorFuncs) Build() (FruitsVisitor, error) {
+	if f.OnApple == nil {
+		if f.Default == nil {
+			return nil, fmt.Errorf("FruitsVisitorFuncs: no handler for Apple")
+		}
+		f.OnApple = func(e Apple) string {
+			return f.Default(e)
+		}
+	}
+	if f.OnOrange == nil {
+		if f.Default == nil {
+			return nil, fmt.Errorf("FruitsVisitorFuncs: no handler for Orange")
+		}
+		f.OnOrange = func(e Orange) string {
+			return f.Default(e)
+		}
+	}
+	if f.OnGrape == nil {
+		if f.Default == nil {
+			return nil, fmt.Errorf("FruitsVisitorFuncs: no handler for Grape")
+		}
+		f.OnGrape = func(e Grape) string {
+			return f.Default(e)
+		}
+	}
+	return __FruitsVisitorFuncs(f), nil
+}
+func (v __FruitsVisitorFuncs) VisitApple(e Apple) string {
+	return v.OnApple(e)
+}
+func (v __FruitsVisitorFuncs) VisitOrange(e Orange) string {
+	return v.OnOrange(e)
+}
+func (v __FruitsVisitorFuncs) VisitGrape(e Grape) string {
+	return v.OnGrape(e)
+}
+
+type (
+	ShapeVisitor interface {
+		VisitCircle(ctx context.Context, e Circle, arg0 float64) (float64, error)
+		VisitSquare(ctx context.Context, e Square, arg0 float64) (float64, error)
+	}
+	ShapeEnum interface {
+		Accept(ctx context.Context, v ShapeVisitor, arg0 float64) (float64, error)
+	}
+)
+
+func (e Circle) Accept(ctx context.Context, v ShapeVisitor, arg0 float64) (float64, error) {
+	return v.VisitCircle(ctx, e, arg0)
+}
+func (e Square) Accept(ctx context.Context, v ShapeVisitor, arg0 float64) (float64, error) {
+	return v.VisitSquare(ctx, e, arg0)
+}
+
+var _ = []ShapeEnum{Circle{}, Square{}}
+
+type (
+	ShapeVisitorHandlers struct {
+		OnCircle func(ctx context.Context, e Circle, arg0 float64) (float64, error)
+		OnSquare func(ctx context.Context, e Square, arg0 float64) (float64, error)
+		Default  func(ctx context.Context, e ShapeEnum, arg0 float64) (float64, error)
+	}
+	__ShapeVisitorHandlers ShapeVisitorHandlers
+)
+
+func (f ShapeVisitorHandlers) Build() (ShapeVisitor, error) {
+	if f.OnCircle == nil {
+		if f.Default == nil {
+			return nil, fmt.Errorf("ShapeVisitorHandlers: no handler for Circle")
+		}
+		f.OnCircle = func(ctx context.Context, e Circle, arg0 float64) (float64, error) {
+			return f.Default(ctx, e, arg0)
+		}
+	}
+	if f.OnSquare == nil {
+		if f.Default == nil {
+			return nil, fmt.Errorf("ShapeVisitorHandlers: no handler for Square")
+		}
+		f.OnSquare = func(ctx context.Context, e Square, arg0 float64) (float64, error) {
+			return f.Default(ctx, e, arg0)
+		}
+	}
+	return __ShapeVisitorHandlers(f), nil
+}
+func (v __ShapeVisitorHandlers) VisitCircle(ctx context.Context, e Circle, arg0 float64) (float64, error) {
+	return v.OnCircle(ctx, e, arg0)
+}
+func (v __ShapeVisitorHandlers) VisitSquare(ctx context.Context, e Square, arg0 float64) (float64, error) {
+	return v.OnSquare(ctx, e, arg0)
+}
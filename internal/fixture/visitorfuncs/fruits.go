@@ -0,0 +1,38 @@
+// Package visitorfuncs is the fixture of visitors built from handler funcs with default fallback.
+package visitorfuncs
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --visitor-funcs="Fruits" --visitor-funcs="Shape:*Handlers"
+
+type (
+	Fruits interface {
+		enum.VisitorReturns[string]
+	}
+	Apple struct {
+		enum.MemberOf[Fruits]
+		Variety string
+	}
+	Orange struct {
+		enum.MemberOf[Fruits]
+	}
+	Grape struct {
+		enum.MemberOf[Fruits]
+	}
+)
+
+type (
+	Shape interface {
+		enum.VisitorContext
+		enum.VisitorArgs[float64]
+		enum.VisitorReturns2[float64, error]
+	}
+	Circle struct {
+		enum.MemberOf[Shape]
+		R float64
+	}
+	Square struct {
+		enum.MemberOf[Shape]
+		Side float64
+	}
+)
@@ -0,0 +1,89 @@
+package visitorfuncs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFruitsVisitorFuncsDefault(t *testing.T) {
+	v, err := FruitsVisitorFuncs{
+		OnApple: func(e Apple) string { return "apple:" + e.Variety },
+		Default: func(e FruitsEnum) string { return fmt.Sprintf("default:%T", e) },
+	}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		e    FruitsEnum
+		want string
+	}{
+		{Apple{Variety: "Fuji"}, "apple:Fuji"},
+		{Orange{}, "default:visitorfuncs.Orange"},
+		{Grape{}, "default:visitorfuncs.Grape"},
+	} {
+		if got := c.e.Accept(v); got != c.want {
+			t.Errorf("%T: got %q, want %q", c.e, got, c.want)
+		}
+	}
+}
+
+func TestFruitsVisitorFuncsBuild(t *testing.T) {
+	// all handlers without Default
+	funcs := FruitsVisitorFuncs{
+		OnApple:  func(e Apple) string { return "apple" },
+		OnOrange: func(e Orange) string { return "orange" },
+		OnGrape:  func(e Grape) string { return "grape" },
+	}
+	v, err := funcs.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (Grape{}).Accept(v); got != "grape" {
+		t.Errorf("got %q", got)
+	}
+
+	// the visitor is not affected by the later change of the funcs
+	funcs.OnGrape = nil
+	if got := (Grape{}).Accept(v); got != "grape" {
+		t.Errorf("got %q", got)
+	}
+
+	_, err = funcs.Build()
+	if err == nil || err.Error() != "FruitsVisitorFuncs: no handler for Grape" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := (FruitsVisitorFuncs{}).Build(); err == nil {
+		t.Error("error expected for empty funcs")
+	}
+}
+
+type ctxKey struct{}
+
+// Default receives the context and the additional argument of the visit.
+func TestShapeVisitorHandlers(t *testing.T) {
+	errUnknown := errors.New("unknown shape")
+	v, err := ShapeVisitorHandlers{
+		OnCircle: func(ctx context.Context, e Circle, scale float64) (float64, error) {
+			return 3 * e.R * e.R * scale, nil
+		},
+		Default: func(ctx context.Context, e ShapeEnum, scale float64) (float64, error) {
+			if ctx.Value(ctxKey{}) != "value" {
+				t.Error("context is not passed to Default")
+			}
+			return scale, errUnknown
+		},
+	}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	if got, err := (Circle{R: 2}).Accept(ctx, v, 0.5); got != 6 || err != nil {
+		t.Errorf("unexpected result: %v, %v", got, err)
+	}
+	if got, err := (Square{Side: 2}).Accept(ctx, v, 0.5); got != 0.5 || !errors.Is(err, errUnknown) {
+		t.Errorf("unexpected result: %v, %v", got, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package visitorreturns
+
+type (
+	Returns0Visitor interface {
+		VisitValue0(e Value0)
+	}
+	Returns0Enum interface {
+		Accept(v Returns0Visitor)
+	}
+)
+
+func (e Value0) Accept(v Returns0Visitor) {
+	v.VisitValue0(e)
+}
+
+var _ = []Returns0Enum{Value0{}}
+
+type __Returns0Visitor struct {
+	__VisitValue0 func(Value0)
+}
+
+func NewReturns0Visitor(__VisitValue0 func(e Value0)) Returns0Visitor {
+	return &__Returns0Visitor{__VisitValue0: __VisitValue0}
+}
+func (v __Returns0Visitor) VisitValue0(e Value0) {
+	v.__VisitValue0(e)
+}
+
+type (
+	Returns1Visitor interface {
+		VisitValue1(e Value1) int
+	}
+	Returns1Enum interface {
+		Accept(v Returns1Visitor) int
+	}
+)
+
+func (e Value1) Accept(v Returns1Visitor) int {
+	return v.VisitValue1(e)
+}
+
+var _ = []Returns1Enum{Value1{}}
+
+type __Returns1Visitor struct {
+	__VisitValue1 func(Value1) int
+}
+
+func NewReturns1Visitor(__VisitValue1 func(e Value1) int) Returns1Visitor {
+	return &__Returns1Visitor{__VisitValue1: __VisitValue1}
+}
+func (v __Returns1Visitor) VisitValue1(e Value1) int {
+	return v.__VisitValue1(e)
+}
+
+type (
+	Returns2Visitor interface {
+		VisitValue2(e Value2) (int, error)
+	}
+	Returns2Enum interface {
+		Accept(v Returns2Visitor) (int, error)
+	}
+)
+
+func (e Value2) Accept(v Returns2Visitor) (int, error) {
+	return v.VisitValue2(e)
+}
+
+var _ = []Returns2Enum{Value2{}}
+
+type __Returns2Visitor struct {
+	__VisitValue2 func(Value2) (int, error)
+}
+
+func NewReturns2Visitor(__VisitValue2 func(e Value2) (int, error)) Returns2Visitor {
+	return &__Returns2Visitor{__VisitValue2: __VisitValue2}
+}
+func (v __Returns2Visitor) VisitValue2(e Value2) (int, error) {
+	return v.__VisitValue2(e)
+}
+
+type (
+	Returns3Visitor interface {
+		VisitValue3(e Value3) (string, int, error)
+	}
+	Returns3Enum interface {
+		Accept(v Returns3Visitor) (string, int, error)
+	}
+)
+
+func (e Value3) Accept(v Returns3Visitor) (string, int, error) {
+	return v.VisitValue3(e)
+}
+
+var _ = []Returns3Enum{Value3{}}
+
+type __Returns3Visitor struct {
+	__VisitValue3 func(Value3) (string, int, error)
+}
+
+func NewReturns3Visitor(__VisitValue3 func(e Value3) (string, int, error)) Returns3Visitor {
+	return &__Returns3Visitor{__VisitValue3: __VisitValue3}
+}
+func (v __Returns3Visitor) VisitValue3(e Value3) (string, int, error) {
+	return v.__VisitValue3(e)
+}
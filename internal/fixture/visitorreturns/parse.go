@@ -0,0 +1,40 @@
+// Package visitorreturns is the fixture of visitor methods returning values of each arity.
+package visitorreturns
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --visitor-impl="*"
+
+type (
+	Returns0 interface{}
+	Value0   struct {
+		enum.MemberOf[Returns0]
+	}
+)
+
+type (
+	Returns1 interface {
+		enum.VisitorReturns[int]
+	}
+	Value1 struct {
+		enum.MemberOf[Returns1]
+	}
+)
+
+type (
+	Returns2 interface {
+		enum.VisitorReturns2[int, error]
+	}
+	Value2 struct {
+		enum.MemberOf[Returns2]
+	}
+)
+
+type (
+	Returns3 interface {
+		enum.VisitorReturns3[string, int, error]
+	}
+	Value3 struct {
+		enum.MemberOf[Returns3]
+	}
+)
@@ -0,0 +1,37 @@
+package visitorreturns
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAcceptReturns(t *testing.T) {
+	var visited bool
+	(Value0{}).Accept(NewReturns0Visitor(func(e Value0) {
+		visited = true
+	}))
+	if !visited {
+		t.Error("visitor without return values is not called")
+	}
+
+	if n := (Value1{}).Accept(NewReturns1Visitor(func(e Value1) int {
+		return 1
+	})); n != 1 {
+		t.Errorf("unexpected result: %d", n)
+	}
+
+	errValue := errors.New("value")
+	n, err := (Value2{}).Accept(NewReturns2Visitor(func(e Value2) (int, error) {
+		return 2, errValue
+	}))
+	if n != 2 || !errors.Is(err, errValue) {
+		t.Errorf("unexpected results: %d, %v", n, err)
+	}
+
+	s, n, err := (Value3{}).Accept(NewReturns3Visitor(func(e Value3) (string, int, error) {
+		return "three", 3, errValue
+	}))
+	if s != "three" || n != 3 || !errors.Is(err, errValue) {
+		t.Errorf("unexpected results: %q, %d, %v", s, n, err)
+	}
+}
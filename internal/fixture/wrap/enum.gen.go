@@ -0,0 +1,84 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package wrap
+
+type (
+	PaymentVisitor interface {
+		VisitCard(e Card) error
+		VisitBankTransfer(e BankTransfer) error
+		VisitCash(e Cash) error
+	}
+	PaymentEnum interface {
+		Accept(v PaymentVisitor) error
+	}
+)
+
+func (e Visa) Accept(v PaymentVisitor) error {
+	return v.VisitCard(e)
+}
+func (e BankTransfer) Accept(v PaymentVisitor) error {
+	return v.VisitBankTransfer(e)
+}
+func (e Cash) Accept(v PaymentVisitor) error {
+	return v.VisitCash(e)
+}
+
+var _ = []PaymentEnum{Visa{}, BankTransfer{}, Cash{}}
+
+type __PaymentVisitor struct {
+	__VisitCard         func(Card) error
+	__VisitBankTransfer func(BankTransfer) error
+	__VisitCash         func(Cash) error
+}
+
+func NewPaymentVisitor(__VisitCard func(e Card) error, __VisitBankTransfer func(e BankTransfer) error, __VisitCash func(e Cash) error) PaymentVisitor {
+	return &__PaymentVisitor{__VisitCard: __VisitCard, __VisitBankTransfer: __VisitBankTransfer, __VisitCash: __VisitCash}
+}
+func (v __PaymentVisitor) VisitCard(e Card) error {
+	return v.__VisitCard(e)
+}
+func (v __PaymentVisitor) VisitBankTransfer(e BankTransfer) error {
+	return v.__VisitBankTransfer(e)
+}
+func (v __PaymentVisitor) VisitCash(e Cash) error {
+	return v.__VisitCash(e)
+}
+func WrapPaymentVisitor(v PaymentVisitor, mw func(kind string, next func() error) error) PaymentVisitor {
+	return __WrapPaymentVisitor{v: v, mw: mw}
+}
+
+type __WrapPaymentVisitor struct {
+	v  PaymentVisitor
+	mw func(kind string, next func() error) error
+}
+
+func (w __WrapPaymentVisitor) VisitCard(e Card) error {
+	return w.mw("Card", func() error {
+		return w.v.VisitCard(e)
+	})
+}
+func (w __WrapPaymentVisitor) VisitBankTransfer(e BankTransfer) error {
+	return w.mw("bank_transfer", func() error {
+		return w.v.VisitBankTransfer(e)
+	})
+}
+func (w __WrapPaymentVisitor) VisitCash(e Cash) error {
+	return w.mw("Cash", func() error {
+		return w.v.VisitCash(e)
+	})
+}
+
+type (
+	CardVisitor interface {
+		VisitVisa(e Visa)
+	}
+	CardEnum interface {
+		AcceptCard(v CardVisitor)
+	}
+)
+
+func (e Visa) AcceptCard(v CardVisitor) {
+	v.VisitVisa(e)
+}
+
+var _ = []CardEnum{Visa{}}
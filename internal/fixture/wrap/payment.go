@@ -0,0 +1,25 @@
+// Package wrap is the fixture of visitor middleware on members with configured names.
+package wrap
+
+import "github.com/daichitakahashi/go-enum"
+
+//go:generate go run github.com/daichitakahashi/go-enum/cmd/enumgen@latest --wrap="Payment" --visitor-impl="Payment" --accept="Card:AcceptCard"
+
+type (
+	Payment interface {
+		enum.VisitorReturns[error]
+		PaymentEnum
+	}
+	Card interface {
+		enum.SubEnumOf[Payment]
+	}
+	Visa struct {
+		enum.MemberOf[Card] `enum:"name=visa"`
+	}
+	BankTransfer struct {
+		enum.MemberOf[Payment] `enum:"name=bank_transfer"`
+	}
+	Cash struct {
+		enum.MemberOf[Payment]
+	}
+)
@@ -0,0 +1,31 @@
+package wrap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapPaymentVisitorKind(t *testing.T) {
+	v := NewPaymentVisitor(
+		func(e Card) error { return nil },
+		func(e BankTransfer) error { return nil },
+		func(e Cash) error { return nil },
+	)
+
+	var kinds []string
+	v = WrapPaymentVisitor(v, func(kind string, next func() error) error {
+		kinds = append(kinds, kind)
+		return next()
+	})
+	for _, e := range []PaymentEnum{Visa{}, BankTransfer{}, Cash{}} {
+		if err := e.Accept(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// configured member names, and the nested enum identifier
+	want := []string{"Card", "bank_transfer", "Cash"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got %q, want %q", kinds, want)
+	}
+}